@@ -0,0 +1,239 @@
+package tomasql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StringAgg concatenates the values of expr, separated by separator. Use OrderBy to sort the values.
+func StringAgg(expr ParametricSql, separator string) *OrderedAggregate[string] {
+	return newOrderedAggregate[string]("STRING_AGG", FeatureStringAgg, expr, Literal(separator))
+}
+
+// ArrayAgg collects the values of expr into an array. Use OrderBy to sort the values.
+func ArrayAgg[T any](expr ParametricSql) *OrderedAggregate[[]T] {
+	return newOrderedAggregate[[]T]("ARRAY_AGG", FeatureArrayAgg, expr)
+}
+
+// AggregateCol is an aggregate function, e.g. COUNT or SUM. Unlike other functions, it can be restricted with
+// Filter and turned into a window function with Over.
+type AggregateCol[T any] struct {
+	*FuncCol[T]
+}
+
+var (
+	_ FuncColumn     = &AggregateCol[int]{}
+	_ rowsAggregator = &AggregateCol[int]{}
+)
+
+// rowsAggregator is implemented by the functions computed over several rows, i.e. aggregate and window functions.
+type rowsAggregator interface {
+	aggregatesRows()
+}
+
+func newAggregateCol[T any](funcName string, inner ParametricSql) *AggregateCol[T] {
+	return &AggregateCol[T]{FuncCol: newFuncCol[T](funcName, inner)}
+}
+
+func (a *AggregateCol[T]) aggregatesRows() {}
+
+// Over turns the aggregate into a window function computed over w.
+func (a *AggregateCol[T]) Over(w *WindowDef) *AggregateCol[T] {
+	a.over = w
+	return a
+}
+
+// Filter restricts the aggregate to the rows matching cond. Dialects without FILTER get the equivalent CASE rewrite
+// of the arguments, e.g. COUNT(CASE WHEN cond THEN 1 END).
+func (a *AggregateCol[T]) Filter(cond Condition) *AggregateCol[T] {
+	a.filter = cond
+	return a
+}
+
+// As sets the alias of the aggregate. The returned column is the aggregate itself.
+func (a *AggregateCol[T]) As(alias string) FuncColumn {
+	a.FuncCol.As(alias)
+	return a
+}
+
+// OrderedAggregate is an aggregate function whose result depends on the order of the aggregated values, e.g.
+// STRING_AGG(name, ', ' ORDER BY name). It embeds AggregateCol, so until OrderBy is called it can be aliased,
+// filtered, compared and used as a window function.
+type OrderedAggregate[T any] struct {
+	*AggregateCol[T]
+	args *orderedArgs
+}
+
+var _ FuncColumn = &OrderedAggregate[string]{}
+
+func newOrderedAggregate[T any](funcName string, feature Feature, args ...ParametricSql) *OrderedAggregate[T] {
+	orderedArgs := &orderedArgs{feature: feature, args: args}
+	return &OrderedAggregate[T]{AggregateCol: newAggregateCol[T](funcName, orderedArgs), args: orderedArgs}
+}
+
+// OrderBy sorts the aggregated values. A sorted aggregate cannot be used as a window function.
+func (a *OrderedAggregate[T]) OrderBy(column SortColumn, columns ...SortColumn) *SortedAggregateCol[T] {
+	a.args.orderBy = append([]SortColumn{column}, columns...)
+	return &SortedAggregateCol[T]{FuncCol: a.FuncCol}
+}
+
+// As sets the alias of the aggregate. The returned column is the aggregate itself.
+func (a *OrderedAggregate[T]) As(alias string) FuncColumn {
+	a.AggregateCol.As(alias)
+	return a
+}
+
+// orderedArgs renders the arguments of an aggregate followed by their ORDER BY, e.g. `name, ', ' ORDER BY name ASC`.
+type orderedArgs struct {
+	// feature is the aggregate function the arguments belong to
+	feature Feature
+	args    []ParametricSql
+	orderBy []SortColumn
+}
+
+func (o *orderedArgs) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	requireFeature(params.Dialect(), o.feature)
+	argsSql := make([]string, len(o.args))
+	for i, arg := range o.args {
+		argsSql[i], params = arg.SqlWithParams(params, ctx)
+	}
+	sql := strings.Join(argsSql, ", ")
+	if len(o.orderBy) > 0 {
+		requireFeature(params.Dialect(), FeatureAggregateOrderBy)
+		var orderBySql string
+		orderBySql, params = aggregateOrderBySql(o.orderBy, params)
+		sql += " " + orderBySql
+	}
+	return sql, params
+}
+
+// withCondition restricts the aggregated values: the first argument is the aggregated one.
+func (o *orderedArgs) withCondition(d Dialect, cond Condition) ParametricSql {
+	args := append([]ParametricSql{filteredArgs(d, o.args[0], cond)}, o.args[1:]...)
+	return &orderedArgs{feature: o.feature, args: args, orderBy: o.orderBy}
+}
+
+// PercentileCont returns the value at fraction (between 0 and 1) of the sorted values, interpolating between adjacent
+// values if needed. The values are given by WithinGroup.
+func PercentileCont(fraction float64) *OrderedSetAggregate[float64] {
+	return &OrderedSetAggregate[float64]{funcName: "PERCENTILE_CONT", args: []ParametricSql{NewFixedCol(fraction, nil)}}
+}
+
+// PercentileDisc returns the first of the sorted values whose position is at least fraction (between 0 and 1). The
+// values are given by WithinGroup.
+func PercentileDisc[T any](fraction float64) *OrderedSetAggregate[T] {
+	return &OrderedSetAggregate[T]{funcName: "PERCENTILE_DISC", args: []ParametricSql{NewFixedCol(fraction, nil)}}
+}
+
+// SortedAggregateCol is an aggregate function over sorted values, e.g. STRING_AGG(name, ', ' ORDER BY name) or
+// PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY price). Unlike AggregateCol, it has no Over: the databases do not
+// accept sorted aggregates as window functions.
+type SortedAggregateCol[T any] struct {
+	*FuncCol[T]
+}
+
+var (
+	_ FuncColumn     = &SortedAggregateCol[int]{}
+	_ rowsAggregator = &SortedAggregateCol[int]{}
+)
+
+func (a *SortedAggregateCol[T]) aggregatesRows() {}
+
+// Filter restricts the aggregate to the rows matching cond, see AggregateCol.Filter.
+func (a *SortedAggregateCol[T]) Filter(cond Condition) *SortedAggregateCol[T] {
+	a.filter = cond
+	return a
+}
+
+// As sets the alias of the aggregate. The returned column is the aggregate itself.
+func (a *SortedAggregateCol[T]) As(alias string) FuncColumn {
+	a.FuncCol.As(alias)
+	return a
+}
+
+// OrderedSetAggregate is an aggregate function computed over sorted values, e.g.
+// PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY price). It is complete once WithinGroup is called.
+type OrderedSetAggregate[T any] struct {
+	funcName string
+	args     []ParametricSql
+}
+
+// WithinGroup sets the sorted values the aggregate is computed over.
+func (a *OrderedSetAggregate[T]) WithinGroup(column SortColumn) *SortedAggregateCol[T] {
+	return &SortedAggregateCol[T]{FuncCol: newExprCol[T](&withinGroupSql{funcName: a.funcName, args: a.args, orderBy: column})}
+}
+
+type withinGroupSql struct {
+	funcName string
+	args     []ParametricSql
+	orderBy  SortColumn
+}
+
+func (w *withinGroupSql) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	requireFeature(params.Dialect(), FeatureWithinGroup)
+	argsSql := make([]string, len(w.args))
+	for i, arg := range w.args {
+		argsSql[i], params = arg.SqlWithParams(params, ctx)
+	}
+	orderBySql, params := aggregateOrderBySql([]SortColumn{w.orderBy}, params)
+	return fmt.Sprintf("%s(%s) WITHIN GROUP (%s)", params.Dialect().FunctionName(w.funcName), strings.Join(argsSql, ", "), orderBySql), params
+}
+
+// withCondition restricts the sorted values, which are the aggregated ones.
+func (w *withinGroupSql) withCondition(d Dialect, cond Condition) ParametricSql {
+	sorted, ok := w.orderBy.(sortTerm)
+	if !ok {
+		panic(&UnsupportedFeatureError{Dialect: d.Name(), Feature: FeatureFilter})
+	}
+	orderBy := &SortCol[any]{subQuery: filteredArgs(d, sorted.sortExpression(), cond), direction: sorted.sortDirection()}
+	return &withinGroupSql{funcName: w.funcName, args: w.args, orderBy: orderBy}
+}
+
+// aggregateOrderBySql renders the ORDER BY of an aggregate call or of a window specification. Output aliases are not
+// visible there, so the sorted expressions are rendered in full.
+func aggregateOrderBySql(orderBy []SortColumn, params *ParamsMap) (string, *ParamsMap) {
+	colsSql := make([]string, len(orderBy))
+	for i, col := range orderBy {
+		sorted, ok := col.(sortTerm)
+		if !ok {
+			colsSql[i], params = col.SqlWithParams(params, OrderByContext)
+			continue
+		}
+		var exprSql string
+		exprSql, params = sorted.sortExpression().SqlWithParams(params, ReferenceContext)
+		colsSql[i] = exprSql + " " + string(sorted.sortDirection())
+	}
+	return "ORDER BY " + strings.Join(colsSql, ", "), params
+}
+
+// distinctArgs renders the arguments of an aggregate over distinct values, e.g. `DISTINCT a, b`.
+type distinctArgs struct {
+	cols []ParametricSql
+}
+
+func (d *distinctArgs) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	colsSql := make([]string, len(d.cols))
+	for i, col := range d.cols {
+		colsSql[i], params = col.SqlWithParams(params, ctx)
+	}
+	return "DISTINCT " + strings.Join(colsSql, ", "), params
+}
+
+// withCondition restricts the distinct values. Rows of several columns cannot be turned into NULL with CASE.
+func (d *distinctArgs) withCondition(dialect Dialect, cond Condition) ParametricSql {
+	if len(d.cols) > 1 {
+		panic(&UnsupportedFeatureError{Dialect: dialect.Name(), Feature: FeatureFilter})
+	}
+	return &distinctArgs{cols: []ParametricSql{filteredArgs(dialect, d.cols[0], cond)}}
+}
+
+// filteredArgs rewrites the arguments of an aggregate function so that it ignores the rows not matching cond, for
+// dialects without FILTER: aggregates skip NULL values, which is what CASE yields for those rows.
+func filteredArgs(d Dialect, args ParametricSql, cond Condition) ParametricSql {
+	if conditional, ok := args.(interface {
+		withCondition(Dialect, Condition) ParametricSql
+	}); ok {
+		return conditional.withCondition(d, cond)
+	}
+	return &caseSql{whens: []caseWhen{{cond: cond, value: args}}}
+}
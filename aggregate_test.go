@@ -0,0 +1,146 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("native filter", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureFilter}})
+
+		sql, params := Select(
+			Account.Type,
+			Count().Filter(Account.CreatedTs.GtParam(10)).As("recent"),
+			Sum[int](Account.CreatedTs).Filter(Account.Uuid.LikeParam("a%")).As("total"),
+		).From(Account).
+			Where(Account.Type.NeqParam("guest")).
+			GroupBy(Account.Type).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, COUNT(1) FILTER (WHERE account.created_ts > $1) AS recent, "+
+			"SUM(account.created_ts) FILTER (WHERE account.uuid LIKE $2) AS total "+
+			"FROM account WHERE account.type <> $3 GROUP BY account.type", sql)
+		require.Equal(t, []any{10, "a%", "guest"}, params)
+	})
+
+	t.Run("case fallback", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		sql, params := Select(
+			Count().Filter(Account.CreatedTs.GtParam(10)).As("recent"),
+			Avg[float64](Account.CreatedTs).Filter(Account.Type.EqParam("admin")),
+			CountDistinct(Account.Uuid).Filter(Account.Type.EqParam("admin")),
+		).From(Account).SQL()
+
+		require.Equal(t, "SELECT COUNT(CASE WHEN account.created_ts > $1 THEN 1 END) AS recent, "+
+			"AVG(CASE WHEN account.type = $2 THEN account.created_ts END), "+
+			"COUNT(DISTINCT CASE WHEN account.type = $2 THEN account.uuid END) FROM account", sql)
+		require.Equal(t, []any{10, "admin"}, params)
+	})
+
+	t.Run("fallback of several distinct columns", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		query := Select(CountDistinct(Account.Uuid, Account.Type).Filter(IdentityCond)).From(Account)
+		require.PanicsWithError(t, "tomasql: FILTER is not supported by the numbered dialect", func() {
+			query.SQL()
+		})
+	})
+
+	t.Run("filter with window", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureFilter}})
+
+		sql, _ := Select(Count().Filter(Account.Type.Eq(Account.Uuid)).Over(Window().PartitionBy(Account.Type))).
+			From(Account).
+			SQL()
+
+		require.Equal(t, "SELECT COUNT(1) FILTER (WHERE account.type = account.uuid) OVER (PARTITION BY account.type) FROM account", sql)
+	})
+}
+
+func TestOrderedAggregates(t *testing.T) {
+	t.Run("string agg", func(t *testing.T) {
+		uuid := Account.Uuid.As("account_uuid")
+		sql, _ := Select(Account.Type, uuid, StringAgg(Account.Uuid, ", ").OrderBy(uuid.Desc(), Account.Id.Asc()).As("uuids")).
+			From(Account).
+			GroupBy(Account.Type, Account.Uuid).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, account.uuid AS account_uuid, "+
+			"STRING_AGG(account.uuid, ', ' ORDER BY account.uuid DESC, account.id ASC) AS uuids "+
+			"FROM account GROUP BY account.type, account.uuid", sql)
+	})
+
+	t.Run("array agg", func(t *testing.T) {
+		sql, _ := Select(ArrayAgg[int64](ShoppingCart.Id)).From(ShoppingCart).SQL()
+		require.Equal(t, "SELECT ARRAY_AGG(shopping_cart.id) FROM shopping_cart", sql)
+	})
+
+	t.Run("filtered array agg fallback", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureArrayAgg, FeatureAggregateOrderBy}})
+
+		sql, params := Select(ArrayAgg[int64](ShoppingCart.Id).OrderBy(ShoppingCart.CreatedTs.Asc()).
+			Filter(ShoppingCart.ArchivedTs.IsNull().And(ShoppingCart.OwnerId.EqParam(int64(3))))).
+			From(ShoppingCart).
+			SQL()
+
+		require.Equal(t, "SELECT ARRAY_AGG(CASE WHEN shopping_cart.archived_ts IS NULL AND shopping_cart.owner_id = $1 "+
+			"THEN shopping_cart.id END ORDER BY shopping_cart.created_ts ASC) FROM shopping_cart", sql)
+		require.Equal(t, []any{int64(3)}, params)
+	})
+
+	t.Run("percentiles", func(t *testing.T) {
+		sql, _ := Select(
+			Account.Type,
+			PercentileCont(0.5).WithinGroup(Account.CreatedTs.Asc()).As("median"),
+			PercentileDisc[int](0.9).WithinGroup(Account.CreatedTs.Desc()),
+		).From(Account).
+			GroupBy(Account.Type).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY account.created_ts ASC) AS median, "+
+			"PERCENTILE_DISC(0.9) WITHIN GROUP (ORDER BY account.created_ts DESC) FROM account GROUP BY account.type", sql)
+	})
+
+	t.Run("filtered percentile", func(t *testing.T) {
+		median := PercentileCont(0.5).WithinGroup(Account.CreatedTs.Asc()).Filter(Account.Type.Eq(Account.Uuid))
+
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureWithinGroup, FeatureFilter}})
+		sql, _ := Select(median).From(Account).SQL()
+		require.Equal(t, "SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY account.created_ts ASC) "+
+			"FILTER (WHERE account.type = account.uuid) FROM account", sql)
+
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureWithinGroup}})
+		sql, _ = Select(median).From(Account).SQL()
+		require.Equal(t, "SELECT PERCENTILE_CONT(0.5) WITHIN GROUP "+
+			"(ORDER BY CASE WHEN account.type = account.uuid THEN account.created_ts END ASC) FROM account", sql)
+	})
+}
+
+func TestAggregateCol(t *testing.T) {
+	t.Run("alias keeps the aggregate", func(t *testing.T) {
+		count := Count().As("c")
+		require.IsType(t, &AggregateCol[int]{}, count)
+
+		sql, _ := Select(count.(*AggregateCol[int]).Filter(Account.Type.Eq(Account.Uuid))).From(Account).SQL()
+		require.Equal(t, "SELECT COUNT(CASE WHEN account.type = account.uuid THEN 1 END) AS c FROM account", sql)
+	})
+
+	t.Run("unsorted ordered aggregate over a window", func(t *testing.T) {
+		sql, _ := Select(StringAgg(Account.Uuid, ", ").Over(Window().PartitionBy(Account.Type))).
+			From(Account).
+			SQL()
+
+		require.Equal(t, "SELECT STRING_AGG(account.uuid, ', ') OVER (PARTITION BY account.type) FROM account", sql)
+	})
+
+	t.Run("alias keeps the sorted aggregate", func(t *testing.T) {
+		names := StringAgg(Account.Uuid, ", ").OrderBy(Account.Uuid.Asc()).As("names")
+		require.IsType(t, &SortedAggregateCol[string]{}, names)
+
+		median := PercentileCont(0.5).WithinGroup(Account.CreatedTs.Asc()).As("median")
+		require.IsType(t, &SortedAggregateCol[float64]{}, median)
+	})
+}
@@ -0,0 +1,163 @@
+package tomasql
+
+type arithOperator string
+
+const (
+	addOperator arithOperator = "+"
+	subOperator arithOperator = "-"
+	mulOperator arithOperator = "*"
+	divOperator arithOperator = "/"
+	modOperator arithOperator = "%"
+)
+
+// precedence returns the binding strength of the operator: multiplicative operators bind tighter than additive ones.
+func (o arithOperator) precedence() int {
+	switch o {
+	case mulOperator, divOperator, modOperator:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// NumExpr is an arithmetic expression over numbers of type T. It embeds FuncCol, so it can be compared, aliased,
+// sorted and passed to aggregates like any function column. Operations never modify the receiver.
+type NumExpr[T Number] struct {
+	*FuncCol[T]
+	arith *arithSql
+}
+
+var (
+	_ FuncColumn      = &NumExpr[int]{}
+	_ Expression[int] = &NumExpr[int]{}
+)
+
+// Num starts an arithmetic expression from a numeric column, function or parameter.
+func Num[T Number](expr Expression[T]) *NumExpr[T] {
+	return newNumExpr[T](&arithSql{left: expr})
+}
+
+func newNumExpr[T Number](arith *arithSql) *NumExpr[T] {
+	return &NumExpr[T]{FuncCol: newExprCol[T](arith), arith: arith}
+}
+
+func (n *NumExpr[T]) Add(other Expression[T]) *NumExpr[T] {
+	return n.apply(addOperator, other)
+}
+
+func (n *NumExpr[T]) AddParam(value T) *NumExpr[T] {
+	return n.apply(addOperator, Param(value))
+}
+
+func (n *NumExpr[T]) Sub(other Expression[T]) *NumExpr[T] {
+	return n.apply(subOperator, other)
+}
+
+func (n *NumExpr[T]) SubParam(value T) *NumExpr[T] {
+	return n.apply(subOperator, Param(value))
+}
+
+func (n *NumExpr[T]) Mul(other Expression[T]) *NumExpr[T] {
+	return n.apply(mulOperator, other)
+}
+
+func (n *NumExpr[T]) MulParam(value T) *NumExpr[T] {
+	return n.apply(mulOperator, Param(value))
+}
+
+// Div divides by other. Note that databases perform integer division when both operands are integers.
+func (n *NumExpr[T]) Div(other Expression[T]) *NumExpr[T] {
+	return n.apply(divOperator, other)
+}
+
+func (n *NumExpr[T]) DivParam(value T) *NumExpr[T] {
+	return n.apply(divOperator, Param(value))
+}
+
+func (n *NumExpr[T]) Mod(other Expression[T]) *NumExpr[T] {
+	return n.apply(modOperator, other)
+}
+
+func (n *NumExpr[T]) ModParam(value T) *NumExpr[T] {
+	return n.apply(modOperator, Param(value))
+}
+
+// As sets the alias of the expression. The returned column is the expression itself.
+func (n *NumExpr[T]) As(alias string) FuncColumn {
+	n.FuncCol.As(alias)
+	return n
+}
+
+func (n *NumExpr[T]) arithmetic() *arithSql {
+	return n.arith
+}
+
+func (n *NumExpr[T]) apply(operator arithOperator, other ParametricSql) *NumExpr[T] {
+	return newNumExpr[T](&arithSql{left: n.arith, operator: operator, right: other})
+}
+
+// arithSql renders `left operator right`, or just left if there is no operator.
+type arithSql struct {
+	left     ParametricSql
+	operator arithOperator
+	right    ParametricSql
+}
+
+func (a *arithSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	leftSql, params := a.left.SqlWithParams(params, ReferenceContext)
+	if a.operator == "" {
+		return leftSql, params
+	}
+	if a.needsParens(a.left, false) {
+		leftSql = "(" + leftSql + ")"
+	}
+	var rightSql string
+	rightSql, params = a.right.SqlWithParams(params, ReferenceContext)
+	if a.needsParens(a.right, true) {
+		rightSql = "(" + rightSql + ")"
+	}
+	return leftSql + " " + string(a.operator) + " " + rightSql, params
+}
+
+// needsParens reports whether an operand must be parenthesized to keep its meaning. Operators are left associative,
+// so the right operand needs them also when its operator has the same precedence.
+func (a *arithSql) needsParens(operand ParametricSql, isRight bool) bool {
+	var inner *arithSql
+	switch op := operand.(type) {
+	case *arithSql:
+		inner = op
+	case interface{ arithmetic() *arithSql }:
+		inner = op.arithmetic()
+	default:
+		return false
+	}
+	if inner.operator == "" {
+		// Num(expr) renders expr as is
+		return a.needsParens(inner.left, isRight)
+	}
+	if isRight {
+		return inner.operator.precedence() <= a.operator.precedence()
+	}
+	return inner.operator.precedence() < a.operator.precedence()
+}
+
+// Concat concatenates strings with the || operator, or the equivalent of the current dialect.
+func Concat(first, second Expression[string], others ...Expression[string]) *FuncCol[string] {
+	parts := []ParametricSql{first, second}
+	for _, other := range others {
+		parts = append(parts, other)
+	}
+	return newExprCol[string](&concatSql{parts: parts})
+}
+
+type concatSql struct {
+	parts []ParametricSql
+}
+
+func (c *concatSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	partsSql := make([]string, len(c.parts))
+	for i, part := range c.parts {
+		partsSql[i], params = part.SqlWithParams(params, ReferenceContext)
+	}
+	return renderOperator(params.Dialect(), OperatorConcat, partsSql...), params
+}
@@ -0,0 +1,94 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumExpr(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     ParametricSql
+		expected string
+	}{
+		{"add", Num(Account.CreatedTs).Add(Config.CreatedTs), "account.created_ts + config.created_ts"},
+		{"sub", Num(Account.CreatedTs).Sub(Config.CreatedTs), "account.created_ts - config.created_ts"},
+		{"mul", Num(Account.Id).Mul(Config.AccountId), "account.id * config.account_id"},
+		{"div", Num(Account.Id).Div(Literal[int64](2)), "account.id / 2"},
+		{"mod", Num(Account.Id).Mod(Literal[int64](10)), "account.id % 10"},
+		{"chained", Num(Account.CreatedTs).Add(Config.CreatedTs).Sub(Literal(1)), "account.created_ts + config.created_ts - 1"},
+		{"left precedence", Num(Account.CreatedTs).Add(Config.CreatedTs).Mul(Literal(2)), "(account.created_ts + config.created_ts) * 2"},
+		{"right precedence", Num(Account.CreatedTs).Mul(Num(Config.CreatedTs).Add(Literal(2))), "account.created_ts * (config.created_ts + 2)"},
+		{"right same precedence", Num(Account.CreatedTs).Sub(Num(Config.CreatedTs).Sub(Literal(2))), "account.created_ts - (config.created_ts - 2)"},
+		{"no parens needed", Num(Account.CreatedTs).Add(Num(Config.CreatedTs).Mul(Literal(2))), "account.created_ts + config.created_ts * 2"},
+		{"wrapped expression", Num(Num(Account.CreatedTs).Add(Literal(1))).Mul(Literal(3)), "(account.created_ts + 1) * 3"},
+		{"function operand", Num(Sum[int](Account.CreatedTs)).Div(Count()), "SUM(account.created_ts) / COUNT(1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _ := tt.expr.SqlWithParams(&ParamsMap{}, ReferenceContext)
+			require.Equal(t, tt.expected, sql)
+		})
+	}
+
+	t.Run("operations do not modify the receiver", func(t *testing.T) {
+		base := Num(Account.CreatedTs)
+		_ = base.Add(Literal(1))
+		sql, _ := base.Mul(Literal(2)).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "account.created_ts * 2", sql)
+	})
+}
+
+func TestNumExprInQuery(t *testing.T) {
+	t.Run("params in every clause", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		age := Num(Account.CreatedTs).SubParam(1000)
+
+		sql, params := Select(Account.Id, age.As("age")).
+			From(Account).
+			Where(Num(Account.CreatedTs).ModParam(7).EqParam(0)).
+			OrderBy(age.Desc()).
+			SQL()
+
+		require.Equal(t, "SELECT account.id, account.created_ts - $1 AS age FROM account "+
+			"WHERE account.created_ts % $2 = $3 ORDER BY age DESC", sql)
+		require.Equal(t, []any{1000, 7, 0}, params)
+	})
+
+	t.Run("inside aggregates", func(t *testing.T) {
+		duration := Num(Config.ArchivedTs).Sub(Config.CreatedTs)
+
+		sql, _ := Select(Config.AccountId, Sum[int](duration).As("total"), Avg[int](duration)).
+			From(Config).
+			GroupBy(Config.AccountId).
+			Having(Sum[int](duration).GtParam(100)).
+			SQL()
+
+		require.Equal(t, "SELECT config.account_id, SUM(config.archived_ts - config.created_ts) AS total, "+
+			"AVG(config.archived_ts - config.created_ts) FROM config GROUP BY config.account_id "+
+			"HAVING SUM(config.archived_ts - config.created_ts) > ?", sql)
+	})
+
+	t.Run("in update assignment", func(t *testing.T) {
+		sql, params := Update(Config).
+			Set(Config.CreatedTs.Set(Num(Config.CreatedTs).AddParam(60))).
+			Where(Config.Uuid.EqParam("c1")).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET created_ts = config.created_ts + ? WHERE config.uuid = ?", sql)
+		require.Equal(t, []any{60, "c1"}, params)
+	})
+}
+
+func TestConcat(t *testing.T) {
+	sql, params := Select(Concat(Account.Type, Literal(":"), Account.Uuid).As("ref")).
+		From(Account).
+		Where(Concat(Account.Type, Param("-x")).LikeParam("a%")).
+		SQL()
+
+	require.Equal(t, "SELECT account.type || ':' || account.uuid AS ref FROM account "+
+		"WHERE account.type || ? LIKE ?", sql)
+	require.Equal(t, []any{"-x", "a%"}, params)
+}
@@ -0,0 +1,52 @@
+package tomasql
+
+import "fmt"
+
+// Assignment is a `column = value` pair, as used in the SET clause of an UPDATE statement.
+type Assignment struct {
+	column Column
+	value  ParametricSql
+}
+
+var _ ParametricSql = &Assignment{}
+
+func newAssignment(column Column, value ParametricSql) *Assignment {
+	return &Assignment{column: column, value: value}
+}
+
+// Column returns the column being assigned.
+func (a *Assignment) Column() Column {
+	return a.column
+}
+
+// checkAssignments panics if one of the assignments of stmt (e.g. Update) is for a column of another table than t.
+// offset is the number of assignments of the statement that were already checked.
+func checkAssignments(stmt string, t Table, offset int, assignments []*Assignment) {
+	for i, assignment := range assignments {
+		if assignment.column.Table() != t {
+			panic(fmt.Sprintf("%s: assignment %d is for column %s of another table, expected a column of %s",
+				stmt, offset+i+1, assignment.column.Name(), t.TableName()))
+		}
+	}
+}
+
+func (a *Assignment) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	// the target column is never qualified: `SET t.col = ...` is not valid in most dialects
+	valueSql, params := a.value.SqlWithParams(params, ReferenceContext)
+	return quoteIdent(params.Dialect(), a.column.Name()) + " = " + valueSql, params
+}
+
+// Set assigns the value of an expression of the same type to the column.
+func (c Col[T]) Set(value Expression[T]) *Assignment {
+	return newAssignment(c, value)
+}
+
+// SetParam assigns a parameter value to the column.
+func (c Col[T]) SetParam(value T) *Assignment {
+	return newAssignment(c, c.Param(value))
+}
+
+// SetNull assigns NULL to the column.
+func (c Col[T]) SetNull() *Assignment {
+	return newAssignment(c, NewCol[T]("NULL", nil))
+}
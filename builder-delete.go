@@ -0,0 +1,84 @@
+package tomasql
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnconditionalDelete is the panic value used when rendering a DELETE statement without a WHERE condition that
+// was not explicitly allowed with All().
+var ErrUnconditionalDelete = errors.New("tomasql: refusing to render DELETE without a WHERE condition, use All() to delete every row")
+
+type builderWithDelete struct {
+	renderer
+	table      Table
+	usingTable []Table
+	where      Condition
+	all        bool
+	returning  []Column
+	with       withClause
+}
+
+var (
+	_ BuilderWithDelete      = &builderWithDelete{}
+	_ BuilderWithDeleteWhere = &builderWithDelete{}
+)
+
+func newBuilderWithDelete(t Table) *builderWithDelete {
+	b := &builderWithDelete{table: t}
+	b.query = b
+	return b
+}
+
+func (b *builderWithDelete) Using(first Table, tables ...Table) BuilderWithDelete {
+	b.usingTable = append(b.usingTable, first)
+	b.usingTable = append(b.usingTable, tables...)
+	for _, t := range b.usingTable {
+		b.with.register(t)
+	}
+	return b
+}
+
+func (b *builderWithDelete) Where(cond Condition) BuilderWithDeleteWhere {
+	b.where = cond
+	return b
+}
+
+func (b *builderWithDelete) All() BuilderWithDeleteWhere {
+	b.all = true
+	return b
+}
+
+func (b *builderWithDelete) Returning(first Column, columns ...Column) SQLable {
+	b.returning = append([]Column{first}, columns...)
+	return b
+}
+
+func (b *builderWithDelete) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	if b.where == nil && !b.all {
+		panic(ErrUnconditionalDelete)
+	}
+
+	var withSql string
+	withSql, params = b.with.SqlWithParams(params, DefinitionContext)
+	var tableSql string
+	tableSql, params = b.table.SqlWithParams(params, DefinitionContext)
+	out := withSql + "DELETE FROM " + tableSql
+
+	if len(b.usingTable) > 0 {
+		requireFeature(params.Dialect(), FeatureDeleteUsing)
+		usingSql := make([]string, len(b.usingTable))
+		for i, t := range b.usingTable {
+			usingSql[i], params = t.SqlWithParams(params, DefinitionContext)
+		}
+		out += " USING " + strings.Join(usingSql, ", ")
+	}
+
+	if b.where != nil {
+		out += " WHERE " + b.where.SQL(params)
+	}
+
+	var returningSql string
+	returningSql, params = renderReturning(b.returning, params)
+	return out + returningSql, params
+}
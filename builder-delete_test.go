@@ -0,0 +1,61 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteFrom(t *testing.T) {
+	t.Run("where", func(t *testing.T) {
+		sql, params := DeleteFrom(Account).Where(Account.Id.EqParam(int64(1))).SQL()
+		require.Equal(t, "DELETE FROM account WHERE account.id = ?", sql)
+		require.Equal(t, []any{int64(1)}, params)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		sql, params := DeleteFrom(Account).All().SQL()
+		require.Equal(t, "DELETE FROM account", sql)
+		require.Empty(t, params)
+	})
+
+	t.Run("aliased table", func(t *testing.T) {
+		a := Account.As("a")
+		sql, _ := DeleteFrom(a).Where(a.CreatedTs.LtParam(100)).SQL()
+		require.Equal(t, "DELETE FROM account AS a WHERE a.created_ts < ?", sql)
+	})
+
+	t.Run("using", func(t *testing.T) {
+		sql, params := DeleteFrom(Config).
+			Using(Account, ShoppingCart).
+			Where(Config.AccountId.Eq(Account.Id).
+				And(ShoppingCart.OwnerId.Eq(Account.Id)).
+				And(Account.Type.EqParam("regular"))).
+			SQL()
+
+		require.Equal(t, "DELETE FROM config USING account, shopping_cart "+
+			"WHERE config.account_id = account.id AND shopping_cart.owner_id = account.id AND account.type = ?", sql)
+		require.Equal(t, []any{"regular"}, params)
+	})
+
+	t.Run("subquery condition", func(t *testing.T) {
+		sub := Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip")).AsSubQuery()
+		sql, params := DeleteFrom(Config).
+			Where(Config.Uuid.EqParam("u1").And(Config.AccountId.In(sub))).
+			SQL()
+
+		require.Equal(t, "DELETE FROM config WHERE config.uuid = ? AND "+
+			"config.account_id IN (SELECT account.id FROM account WHERE account.type = ?)", sql)
+		require.Equal(t, []any{"u1", "vip"}, params)
+	})
+}
+
+func TestDeleteFrom_RefusesUnconditionalDelete(t *testing.T) {
+	require.PanicsWithValue(t, ErrUnconditionalDelete, func() {
+		DeleteFrom(Account).Where(nil).SQL()
+	})
+
+	require.PanicsWithValue(t, ErrUnconditionalDelete, func() {
+		DeleteFrom(Account).Using(Config).Where(nil).SQL()
+	})
+}
@@ -0,0 +1,131 @@
+package tomasql
+
+import (
+	"fmt"
+	"strings"
+)
+
+type builderWithInsert struct {
+	renderer
+	table     Table
+	columns   []Column
+	rows      [][]*ColumnValue
+	upsert    *onConflictClause
+	returning []Column
+}
+
+var (
+	_ BuilderWithInsert        = &builderWithInsert{}
+	_ BuilderWithInsertColumns = &builderWithInsert{}
+	_ BuilderWithInsertValues  = &builderWithInsert{}
+	_ BuilderWithOnConflict    = &builderWithInsert{}
+	_ BuilderWithUpsert        = &builderWithInsert{}
+)
+
+// ColumnValue is the value inserted into a column, as used in the VALUES clause of an INSERT statement.
+type ColumnValue struct {
+	column Column
+	value  ParametricSql
+}
+
+// Column returns the column the value is inserted into.
+func (v *ColumnValue) Column() Column {
+	return v.column
+}
+
+// Value inserts a parameter value into the column.
+func (c Col[T]) Value(value T) *ColumnValue {
+	return &ColumnValue{column: c, value: c.Param(value)}
+}
+
+// ValueOf inserts the value of an expression of the same type into the column.
+func (c Col[T]) ValueOf(value Expression[T]) *ColumnValue {
+	return &ColumnValue{column: c, value: value}
+}
+
+// ValueNull inserts NULL into the column.
+func (c Col[T]) ValueNull() *ColumnValue {
+	return &ColumnValue{column: c, value: NewCol[T]("NULL", nil)}
+}
+
+func newBuilderWithInsert(t Table) *builderWithInsert {
+	b := &builderWithInsert{table: t}
+	b.query = b
+	return b
+}
+
+func (b *builderWithInsert) Columns(first Column, columns ...Column) BuilderWithInsertColumns {
+	b.columns = append([]Column{first}, columns...)
+	return b
+}
+
+// Values adds a row of values. Values must be given in the same order as the columns they are created for.
+func (b *builderWithInsert) Values(first *ColumnValue, values ...*ColumnValue) BuilderWithInsertValues {
+	row := append([]*ColumnValue{first}, values...)
+	if len(row) != len(b.columns) {
+		panic(fmt.Sprintf("InsertInto: row %d has %d values, expected %d", len(b.rows)+1, len(row), len(b.columns)))
+	}
+	for i, value := range row {
+		if !sameColumn(value.column, b.columns[i]) {
+			panic(fmt.Sprintf("InsertInto: value %d of row %d is for column %s, expected %s",
+				i+1, len(b.rows)+1, value.column.Name(), b.columns[i].Name()))
+		}
+	}
+	b.rows = append(b.rows, row)
+	return b
+}
+
+func (b *builderWithInsert) OnConflict(columns ...Column) BuilderWithOnConflict {
+	b.upsert = &onConflictClause{columns: columns}
+	return b
+}
+
+func (b *builderWithInsert) DoUpdateSet(first *Assignment, assignments ...*Assignment) BuilderWithUpsert {
+	assignments = append([]*Assignment{first}, assignments...)
+	checkAssignments("DoUpdateSet", b.table, 0, assignments)
+	b.upsert.assignments = assignments
+	return b
+}
+
+func (b *builderWithInsert) DoNothing() BuilderWithUpsert {
+	b.upsert.assignments = nil
+	return b
+}
+
+func (b *builderWithInsert) Returning(first Column, columns ...Column) SQLable {
+	b.returning = append([]Column{first}, columns...)
+	return b
+}
+
+func (b *builderWithInsert) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	// INSERT has no WITH clause, but the queries in its values are nested in it
+	params.enterQuery()
+	var tableSql string
+	tableSql, params = b.table.SqlWithParams(params, DefinitionContext)
+
+	colNames := make([]string, len(b.columns))
+	for i, col := range b.columns {
+		colNames[i] = quoteIdent(params.Dialect(), col.Name())
+	}
+
+	rowsSql := make([]string, len(b.rows))
+	for i, row := range b.rows {
+		valuesSql := make([]string, len(row))
+		for j, value := range row {
+			valuesSql[j], params = value.value.SqlWithParams(params, ReferenceContext)
+		}
+		rowsSql[i] = "(" + strings.Join(valuesSql, ", ") + ")"
+	}
+
+	out := "INSERT INTO " + tableSql + " (" + strings.Join(colNames, ", ") + ") VALUES " + strings.Join(rowsSql, ", ")
+
+	if b.upsert != nil {
+		var upsertSql string
+		upsertSql, params = b.upsert.SqlWithParams(b.columns, params)
+		out += upsertSql
+	}
+
+	var returningSql string
+	returningSql, params = renderReturning(b.returning, params)
+	return out + returningSql, params
+}
@@ -0,0 +1,92 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertInto(t *testing.T) {
+	t.Run("single row", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.Type, Account.CreatedTs).
+			Values(Account.Uuid.Value("u1"), Account.Type.Value("regular"), Account.CreatedTs.Value(10)).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, type, created_ts) VALUES (?, ?, ?)", sql)
+		require.Equal(t, []any{"u1", "regular", 10}, params)
+	})
+
+	t.Run("multiple rows", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.CreatedTs).
+			Values(Account.Uuid.Value("u1"), Account.CreatedTs.Value(10)).
+			Values(Account.Uuid.Value("u2"), Account.CreatedTs.Value(20)).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, created_ts) VALUES (?, ?), (?, ?)", sql)
+		require.Equal(t, []any{"u1", 10, "u2", 20}, params)
+	})
+
+	t.Run("expressions and null", func(t *testing.T) {
+		sql, params := InsertInto(ShoppingCart).
+			Columns(ShoppingCart.Uuid, ShoppingCart.OwnerId, ShoppingCart.CreatedTs, ShoppingCart.ArchivedTs).
+			Values(ShoppingCart.Uuid.ValueOf(Param("sc1")), ShoppingCart.OwnerId.Value(3),
+				ShoppingCart.CreatedTs.ValueOf(Account.CreatedTs), ShoppingCart.ArchivedTs.ValueNull()).
+			SQL()
+
+		require.Equal(t, "INSERT INTO shopping_cart (uuid, owner_id, created_ts, archived_ts) "+
+			"VALUES (?, ?, account.created_ts, NULL)", sql)
+		require.Equal(t, []any{"sc1", int64(3)}, params)
+	})
+
+	t.Run("positional placeholders repeat values", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Type, Account.CreatedTs).
+			Values(Account.Type.Value("regular"), Account.CreatedTs.Value(10)).
+			Values(Account.Type.Value("regular"), Account.CreatedTs.Value(20)).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (type, created_ts) VALUES (?, ?), (?, ?)", sql)
+		require.Equal(t, []any{"regular", 10, "regular", 20}, params)
+	})
+
+	t.Run("numbered placeholders", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.CreatedTs).
+			Values(Account.Uuid.Value("u1"), Account.CreatedTs.Value(10)).
+			Values(Account.Uuid.Value("u2"), Account.CreatedTs.Value(10)).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, created_ts) VALUES ($1, $2), ($3, $2)", sql)
+		require.Equal(t, []any{"u1", 10, "u2"}, params)
+	})
+}
+
+func TestInsertInto_InvalidValues(t *testing.T) {
+	t.Run("wrong number of values", func(t *testing.T) {
+		require.Panics(t, func() {
+			InsertInto(Account).
+				Columns(Account.Uuid, Account.CreatedTs).
+				Values(Account.Uuid.Value("u1"))
+		})
+	})
+
+	t.Run("value for another column", func(t *testing.T) {
+		require.Panics(t, func() {
+			InsertInto(Account).
+				Columns(Account.Uuid, Account.Type).
+				Values(Account.Type.Value("regular"), Account.Uuid.Value("u1"))
+		})
+	})
+
+	t.Run("value for another table", func(t *testing.T) {
+		require.Panics(t, func() {
+			InsertInto(Account).
+				Columns(Account.Uuid).
+				Values(Config.Uuid.Value("u1"))
+		})
+	})
+}
@@ -10,23 +10,41 @@ type BuilderWithTables interface {
 	LeftJoin(Table) BuilderWithJoin
 	Join(Table) BuilderWithJoin
 	RightJoin(Table) BuilderWithJoin
+	FullJoin(Table) BuilderWithJoin
+	// CrossJoin joins every row of the table, so it takes no join condition.
+	CrossJoin(Table) BuilderWithTables
+	// JoinLateral and LeftJoinLateral join a subquery that can reference the tables that precede it.
+	JoinLateral(Table) BuilderWithJoin
+	LeftJoinLateral(Table) BuilderWithJoin
 
 	Joins(...*JoinItem) BuilderWithTables
 
 	Where(Condition) BuilderWithWhere
 	GroupBy(ParametricSql, ...ParametricSql) BuilderWithGroupBy
+	// Window declares named windows (see WindowDef.As) used by window functions of the query.
+	Window(*WindowDef, ...*WindowDef) BuilderWithWindow
 	OrderBy(SortColumn, ...SortColumn) BuilderWithOrderBy
 }
 
 type BuilderWithJoin interface {
 	SubQueryable
 	On(Condition) BuilderWithTables
+	// Using joins on the columns with the same name in both tables, e.g. USING (account_id).
+	Using(Column, ...Column) BuilderWithTables
 }
 
 type BuilderWithWhere interface {
 	SubQueryable
 	GroupBy(ParametricSql, ...ParametricSql) BuilderWithGroupBy
+	// Window declares named windows (see WindowDef.As) used by window functions of the query.
+	Window(*WindowDef, ...*WindowDef) BuilderWithWindow
 	OrderBy(SortColumn, ...SortColumn) BuilderWithOrderBy
+	// ForUpdate locks the selected rows against concurrent updates and deletes.
+	ForUpdate() BuilderWithLock
+	// ForNoKeyUpdate is a weaker ForUpdate that does not block inserts of rows referencing the locked ones (Postgres).
+	ForNoKeyUpdate() BuilderWithLock
+	// ForShare locks the selected rows against concurrent updates, still allowing other shared locks.
+	ForShare() BuilderWithLock
 }
 
 type BuilderWithGroupBy interface {
@@ -35,6 +53,13 @@ type BuilderWithGroupBy interface {
 }
 
 type BuilderWithHaving interface {
+	SubQueryable
+	// Window declares named windows (see WindowDef.As) used by window functions of the query.
+	Window(*WindowDef, ...*WindowDef) BuilderWithWindow
+	OrderBy(SortColumn, ...SortColumn) BuilderWithOrderBy
+}
+
+type BuilderWithWindow interface {
 	SubQueryable
 	OrderBy(SortColumn, ...SortColumn) BuilderWithOrderBy
 }
@@ -42,27 +67,101 @@ type BuilderWithHaving interface {
 type BuilderWithOrderBy interface {
 	SubQueryable
 	Limit(int) BuilderWithLimit
+	// ForUpdate locks the selected rows against concurrent updates and deletes.
+	ForUpdate() BuilderWithLock
+	// ForNoKeyUpdate is a weaker ForUpdate that does not block inserts of rows referencing the locked ones (Postgres).
+	ForNoKeyUpdate() BuilderWithLock
+	// ForShare locks the selected rows against concurrent updates, still allowing other shared locks.
+	ForShare() BuilderWithLock
 }
 
 type BuilderWithLimit interface {
 	SubQueryable
 	Offset(int) SQLable
+	// ForUpdate locks the selected rows against concurrent updates and deletes.
+	ForUpdate() BuilderWithLock
+	// ForNoKeyUpdate is a weaker ForUpdate that does not block inserts of rows referencing the locked ones (Postgres).
+	ForNoKeyUpdate() BuilderWithLock
+	// ForShare locks the selected rows against concurrent updates, still allowing other shared locks.
+	ForShare() BuilderWithLock
+}
+
+type BuilderWithLock interface {
+	LockedQuery
+	// Of restricts the lock to the rows of the given tables.
+	Of(Table, ...Table) BuilderWithLock
+	// SkipLocked skips the rows that are already locked instead of waiting for them.
+	SkipLocked() LockedQuery
+	// NoWait fails instead of waiting for the rows that are already locked.
+	NoWait() LockedQuery
+}
+
+// LockedQuery is a query with a row locking clause. Unlike SubQueryable, it cannot be combined with set operations,
+// which do not allow locking clauses.
+type LockedQuery interface {
+	SQLable
+	AsNamedSubQuery(string) Table
+	AsSubQuery() SQLable
+}
+
+type BuilderWithSetOperation interface {
+	SubQueryable
+	// OrderBy sorts the combined result, referencing columns of the first query by their output name.
+	OrderBy(SortColumn, ...SortColumn) BuilderWithSetOperationOrderBy
+	Limit(int) BuilderWithSetOperationLimit
+}
+
+// BuilderWithSetOperationOrderBy is the ORDER BY of a set operation. Unlike BuilderWithOrderBy, it cannot lock rows.
+type BuilderWithSetOperationOrderBy interface {
+	SubQueryable
+	Limit(int) BuilderWithSetOperationLimit
+}
+
+type BuilderWithSetOperationLimit interface {
+	SubQueryable
+	Offset(int) SQLable
+}
+
+// SelectModifier renders a clause between SELECT and the select list, e.g. DISTINCT ON in the pgres extension.
+type SelectModifier interface {
+	ParametricSql
+	// ValidateOrderBy checks the ORDER BY of the query, given as its sort expressions without direction, before it
+	// is rendered in dialect d. A non-nil error is raised as a panic.
+	ValidateOrderBy(d Dialect, sortExprs []ParametricSql) error
 }
 
 type SQLable interface {
 	ParametricSql
+	// SQL renders the query with the default dialect, see SetDialect.
 	SQL() (sql string, params []any)
+	// SQLFor renders the query with dialect d, or with the default dialect if d is nil.
+	SQLFor(d Dialect) (sql string, params []any)
+	// RenderWith binds the query to dialect d: SQL of the returned query renders it with d.
+	RenderWith(d Dialect) SQLable
+	// Build renders the query like SQL, but returns the errors that SQL panics with, e.g. an
+	// *UnsupportedFeatureError, instead of panicking.
+	Build() (sql string, params []any, err error)
 }
 
 type ParametricSql interface {
-	// SqlWithParams renders SQL with awareness of the context (SELECT, WHERE, etc.)
-	SqlWithParams(ParamsMap, RenderContext) (string, ParamsMap)
+	// SqlWithParams renders SQL with awareness of the context (SELECT, WHERE, etc.), in the dialect given by
+	// ParamsMap.Dialect.
+	SqlWithParams(*ParamsMap, RenderContext) (string, *ParamsMap)
 }
 
 type SubQueryable interface {
 	SQLable
 	AsNamedSubQuery(string) Table
 	AsSubQuery() SQLable
+
+	// Union combines the rows of both queries, discarding duplicates.
+	Union(SubQueryable) BuilderWithSetOperation
+	// UnionAll combines the rows of both queries, keeping duplicates.
+	UnionAll(SubQueryable) BuilderWithSetOperation
+	// Intersect returns the rows that are in both queries.
+	Intersect(SubQueryable) BuilderWithSetOperation
+	// Except returns the rows of this query that are not in the other one.
+	Except(SubQueryable) BuilderWithSetOperation
 }
 
 type SortDirection string
@@ -78,3 +177,81 @@ type SortColumn interface {
 	// Column returns the underlying Column that this SortColumn represents or nil if it is not a Column (e.g. subquery).
 	Column() Column
 }
+
+type BuilderWithInsert interface {
+	Columns(Column, ...Column) BuilderWithInsertColumns
+}
+
+type BuilderWithInsertColumns interface {
+	Values(*ColumnValue, ...*ColumnValue) BuilderWithInsertValues
+}
+
+type BuilderWithInsertValues interface {
+	SQLable
+	// Values adds another row to the statement.
+	Values(*ColumnValue, ...*ColumnValue) BuilderWithInsertValues
+	// OnConflict turns the statement into an upsert. The columns identify the conflicting unique constraint, and are
+	// required by DoUpdateSet in ON CONFLICT dialects; they are ignored by dialects that use ON DUPLICATE KEY UPDATE.
+	OnConflict(...Column) BuilderWithOnConflict
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
+
+type BuilderWithOnConflict interface {
+	// DoUpdateSet updates the existing row. Use Col[T].Excluded() to reference the values proposed for insertion.
+	DoUpdateSet(*Assignment, ...*Assignment) BuilderWithUpsert
+	DoNothing() BuilderWithUpsert
+}
+
+type BuilderWithUpsert interface {
+	SQLable
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
+
+type BuilderWithUpdate interface {
+	Set(*Assignment, ...*Assignment) BuilderWithUpdateSet
+}
+
+type BuilderWithUpdateSet interface {
+	SQLable
+	Set(*Assignment, ...*Assignment) BuilderWithUpdateSet
+	// From adds a FROM clause (Postgres style UPDATE ... FROM) to join other tables in the update.
+	From(Table) BuilderWithUpdateFrom
+	Where(Condition) BuilderWithUpdateWhere
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
+
+type BuilderWithUpdateFrom interface {
+	SQLable
+	Join(Table) BuilderWithUpdateJoin
+	LeftJoin(Table) BuilderWithUpdateJoin
+	Where(Condition) BuilderWithUpdateWhere
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
+
+type BuilderWithUpdateJoin interface {
+	On(Condition) BuilderWithUpdateFrom
+}
+
+type BuilderWithUpdateWhere interface {
+	SQLable
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
+
+type BuilderWithDelete interface {
+	// Using adds a USING clause (Postgres) to join other tables in the delete.
+	Using(Table, ...Table) BuilderWithDelete
+	Where(Condition) BuilderWithDeleteWhere
+	// All explicitly allows deleting every row of the table.
+	All() BuilderWithDeleteWhere
+}
+
+type BuilderWithDeleteWhere interface {
+	SQLable
+	// Returning adds a RETURNING clause, for dialects that support it.
+	Returning(Column, ...Column) SQLable
+}
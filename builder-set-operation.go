@@ -0,0 +1,152 @@
+package tomasql
+
+type setOperator string
+
+const (
+	unionOperator     setOperator = "UNION"
+	unionAllOperator  setOperator = "UNION ALL"
+	intersectOperator setOperator = "INTERSECT"
+	exceptOperator    setOperator = "EXCEPT"
+)
+
+// precedence returns the binding strength of the operator: INTERSECT binds tighter than UNION and EXCEPT.
+func (o setOperator) precedence() int {
+	if o == intersectOperator {
+		return 2
+	}
+	return 1
+}
+
+type builderWithSetOperation struct {
+	renderer
+	left     SubQueryable
+	right    SubQueryable
+	operator setOperator
+}
+
+var _ BuilderWithSetOperation = &builderWithSetOperation{}
+
+func newBuilderWithSetOperation(left SubQueryable, operator setOperator, right SubQueryable) BuilderWithSetOperation {
+	b := &builderWithSetOperation{
+		left:     left,
+		right:    right,
+		operator: operator,
+	}
+	b.query = b
+	return b
+}
+
+// previousStage returns the left operand, which determines the columns of the result.
+func (b *builderWithSetOperation) previousStage() ParametricSql {
+	return b.left
+}
+
+func (b *builderWithSetOperation) AsNamedSubQuery(alias string) Table {
+	return newWithOptionalAlias(b, &alias)
+}
+
+func (b *builderWithSetOperation) AsSubQuery() SQLable {
+	return newWithOptionalAlias(b, nil)
+}
+
+func (b *builderWithSetOperation) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithSetOperation) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithSetOperation) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithSetOperation) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
+// OrderBy sorts the combined result. Columns are referenced by their output name in the first query (alias or column
+// name), as a set operation result has no table to qualify them with.
+func (b *builderWithSetOperation) OrderBy(column SortColumn, columns ...SortColumn) BuilderWithSetOperationOrderBy {
+	var orderBy []SortColumn
+	items := selectItemsOf(b)
+	for _, col := range append([]SortColumn{column}, columns...) {
+		orderBy = append(orderBy, &resultSortCol{SortColumn: col, selectItems: items})
+	}
+	return &setOperationOrderBy{builderWithOrderBy: newBuilderWithOrderBy(b, orderBy)}
+}
+
+func (b *builderWithSetOperation) Limit(i int) BuilderWithSetOperationLimit {
+	return (&setOperationOrderBy{builderWithOrderBy: newBuilderWithOrderBy(b, nil)}).Limit(i)
+}
+
+func (b *builderWithSetOperation) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	var leftSql, rightSql string
+	leftSql, params = b.left.SqlWithParams(params, ctx)
+	if b.needsParens(b.left, false) {
+		requireFeature(params.Dialect(), FeatureParenthesizedSetOperand)
+		leftSql = "(" + leftSql + ")"
+	}
+	rightSql, params = b.right.SqlWithParams(params, ctx)
+	if b.needsParens(b.right, true) {
+		requireFeature(params.Dialect(), FeatureParenthesizedSetOperand)
+		rightSql = "(" + rightSql + ")"
+	}
+	return leftSql + " " + string(b.operator) + " " + rightSql, params
+}
+
+// needsParens reports whether an operand must be parenthesized to keep its meaning in the combined query.
+func (b *builderWithSetOperation) needsParens(operand SubQueryable, isRight bool) bool {
+	switch op := operand.(type) {
+	case *builderWithOrderBy, *setOperationOrderBy:
+		// ORDER BY and LIMIT would otherwise apply to the whole combined query
+		return true
+	case *builderWithSetOperation:
+		// set operations are left associative, and INTERSECT binds tighter than the others
+		return isRight || op.operator.precedence() < b.operator.precedence()
+	}
+	// a WITH clause can only start the statement
+	with := withClauseOf(operand)
+	return isRight && with != nil && len(with.ctes) > 0
+}
+
+// setOperationOrderBy is the ORDER BY and LIMIT stage of a set operation. It hides the locking methods of
+// builderWithOrderBy, as set operations do not allow locking clauses.
+type setOperationOrderBy struct {
+	*builderWithOrderBy
+}
+
+var (
+	_ BuilderWithSetOperationOrderBy = &setOperationOrderBy{}
+	_ BuilderWithSetOperationLimit   = &setOperationOrderBy{}
+)
+
+func (s *setOperationOrderBy) Limit(i int) BuilderWithSetOperationLimit {
+	s.builderWithOrderBy.Limit(i)
+	return s
+}
+
+// resultSortCol renders a sort column by its output name, so that it can sort the result of a set operation.
+type resultSortCol struct {
+	SortColumn
+	selectItems []ParametricSql
+}
+
+func (s *resultSortCol) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	col := s.Column()
+	directed, ok := s.SortColumn.(sortTerm)
+	if col == nil || !ok {
+		return s.SortColumn.SqlWithParams(params, ctx)
+	}
+	name := col.Name()
+	if col.Alias() != nil {
+		name = *col.Alias()
+	}
+	for _, item := range s.selectItems {
+		if itemName, found := derivedColName(item, col); found {
+			name = itemName
+			break
+		}
+	}
+	return quoteIdent(params.Dialect(), name) + " " + string(directed.sortDirection()), params
+}
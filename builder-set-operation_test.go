@@ -0,0 +1,155 @@
+package tomasql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOperations(t *testing.T) {
+	accounts := func() BuilderWithTables { return Select(Account.Id, Account.Uuid).From(Account) }
+	carts := func() BuilderWithTables { return Select(ShoppingCart.OwnerId, ShoppingCart.Uuid).From(ShoppingCart) }
+
+	t.Run("operators", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    SQLable
+			operator string
+		}{
+			{"Union", accounts().Union(carts()), "UNION"},
+			{"UnionAll", accounts().UnionAll(carts()), "UNION ALL"},
+			{"Intersect", accounts().Intersect(carts()), "INTERSECT"},
+			{"Except", accounts().Except(carts()), "EXCEPT"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sql, _ := tt.query.SQL()
+				require.Equal(t, "SELECT account.id, account.uuid FROM account "+tt.operator+
+					" SELECT shopping_cart.owner_id, shopping_cart.uuid FROM shopping_cart", sql)
+			})
+		}
+	})
+
+	t.Run("params are numbered across branches", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		sql, params := Select(Account.Id).From(Account).Where(Account.Type.EqParam("admin")).
+			UnionAll(Select(Config.AccountId).From(Config).Where(Config.Uuid.EqParam("c1").And(Config.CreatedTs.GtParam(10)))).
+			Union(Select(ShoppingCart.OwnerId).From(ShoppingCart).Where(ShoppingCart.Uuid.EqParam("admin"))).
+			SQL()
+
+		require.Equal(t, "SELECT account.id FROM account WHERE account.type = $1 "+
+			"UNION ALL SELECT config.account_id FROM config WHERE config.uuid = $2 AND config.created_ts > $3 "+
+			"UNION SELECT shopping_cart.owner_id FROM shopping_cart WHERE shopping_cart.uuid = $1", sql)
+		require.Equal(t, []any{"admin", "c1", 10}, params)
+	})
+
+	t.Run("order by and limit", func(t *testing.T) {
+		sql, _ := Select(Account.Id, Account.Uuid.As("ref")).From(Account).
+			Union(carts()).
+			OrderBy(Account.Uuid.Desc(), Account.Id.Asc()).
+			Limit(10).
+			Offset(5).
+			SQL()
+
+		require.Equal(t, "SELECT account.id, account.uuid AS ref FROM account "+
+			"UNION SELECT shopping_cart.owner_id, shopping_cart.uuid FROM shopping_cart "+
+			"ORDER BY ref DESC, id ASC LIMIT 10 OFFSET 5", sql)
+	})
+
+	t.Run("limit without order by", func(t *testing.T) {
+		sql, _ := accounts().Union(carts()).Limit(1).SQL()
+
+		require.Equal(t, "SELECT account.id, account.uuid FROM account "+
+			"UNION SELECT shopping_cart.owner_id, shopping_cart.uuid FROM shopping_cart LIMIT 1", sql)
+	})
+
+	t.Run("operands with order by are parenthesized", func(t *testing.T) {
+		sql, _ := Select(Account.Id).From(Account).OrderBy(Account.CreatedTs.Desc()).Limit(1).
+			UnionAll(Select(Config.AccountId).From(Config).OrderBy(Config.CreatedTs.Desc()).Limit(1)).
+			SQL()
+
+		require.Equal(t, "(SELECT account.id FROM account ORDER BY account.created_ts DESC LIMIT 1) "+
+			"UNION ALL (SELECT config.account_id FROM config ORDER BY config.created_ts DESC LIMIT 1)", sql)
+	})
+
+	t.Run("sorted set operations are parenthesized", func(t *testing.T) {
+		sorted := func() SubQueryable {
+			return Select(Config.AccountId).From(Config).Union(Select(ShoppingCart.OwnerId).From(ShoppingCart)).
+				OrderBy(Config.AccountId.Asc()).Limit(1)
+		}
+
+		sql, _ := Select(Account.Id).From(Account).UnionAll(sorted()).SQL()
+		require.Equal(t, "SELECT account.id FROM account UNION ALL "+
+			"(SELECT config.account_id FROM config UNION SELECT shopping_cart.owner_id FROM shopping_cart "+
+			"ORDER BY account_id ASC LIMIT 1)", sql)
+
+		sql, _ = sorted().UnionAll(Select(Account.Id).From(Account)).SQL()
+		require.Equal(t, "(SELECT config.account_id FROM config UNION SELECT shopping_cart.owner_id FROM shopping_cart "+
+			"ORDER BY account_id ASC LIMIT 1) UNION ALL SELECT account.id FROM account", sql)
+	})
+
+	t.Run("locking is not offered", func(t *testing.T) {
+		for _, stage := range []reflect.Type{
+			reflect.TypeFor[BuilderWithSetOperationOrderBy](),
+			reflect.TypeFor[BuilderWithSetOperationLimit](),
+		} {
+			for _, method := range []string{"ForUpdate", "ForNoKeyUpdate", "ForShare"} {
+				_, found := stage.MethodByName(method)
+				require.False(t, found, "%s.%s", stage.Name(), method)
+			}
+		}
+	})
+
+	t.Run("nested operations keep their grouping", func(t *testing.T) {
+		a := Select(Account.Id).From(Account)
+		c := Select(Config.AccountId).From(Config)
+		s := Select(ShoppingCart.OwnerId).From(ShoppingCart)
+
+		sql, _ := a.Union(c).Intersect(s).SQL()
+		require.Equal(t, "(SELECT account.id FROM account UNION SELECT config.account_id FROM config) "+
+			"INTERSECT SELECT shopping_cart.owner_id FROM shopping_cart", sql)
+
+		sql, _ = a.Intersect(c).Union(s).SQL()
+		require.Equal(t, "SELECT account.id FROM account INTERSECT SELECT config.account_id FROM config "+
+			"UNION SELECT shopping_cart.owner_id FROM shopping_cart", sql)
+
+		sql, _ = a.Except(c.Union(s)).SQL()
+		require.Equal(t, "SELECT account.id FROM account EXCEPT "+
+			"(SELECT config.account_id FROM config UNION SELECT shopping_cart.owner_id FROM shopping_cart)", sql)
+	})
+
+	t.Run("as subquery", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		owners := Select(Config.AccountId).From(Config).Where(Config.Uuid.EqParam("c1")).
+			Union(Select(ShoppingCart.OwnerId).From(ShoppingCart).Where(ShoppingCart.Uuid.EqParam("s1")))
+
+		sql, params := Select(Account.Id).From(Account).
+			Where(Account.Type.EqParam("user").And(Account.Id.In(owners.AsSubQuery()))).
+			SQL()
+
+		require.Equal(t, "SELECT account.id FROM account WHERE account.type = $1 AND account.id IN "+
+			"(SELECT config.account_id FROM config WHERE config.uuid = $2 "+
+			"UNION SELECT shopping_cart.owner_id FROM shopping_cart WHERE shopping_cart.uuid = $3)", sql)
+		require.Equal(t, []any{"user", "c1", "s1"}, params)
+	})
+
+	t.Run("as named subquery", func(t *testing.T) {
+		ids := Select(Account.Id).From(Account).UnionAll(Select(Config.AccountId).From(Config)).AsNamedSubQuery("ids")
+
+		sql, _ := Select(DerivedCol(ids, Account.Id)).From(ids).SQL()
+
+		require.Equal(t, "SELECT ids.id FROM (SELECT account.id FROM account UNION ALL SELECT config.account_id FROM config) AS ids", sql)
+	})
+
+	t.Run("in a cte", func(t *testing.T) {
+		ids := With("ids", Select(Account.Id).From(Account).UnionAll(Select(Config.AccountId).From(Config)))
+
+		sql, _ := Select(DerivedCol(ids, Account.Id)).From(ids).SQL()
+
+		require.Equal(t, "WITH ids AS (SELECT account.id FROM account UNION ALL SELECT config.account_id FROM config) "+
+			"SELECT ids.id FROM ids", sql)
+	})
+}
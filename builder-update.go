@@ -0,0 +1,102 @@
+package tomasql
+
+import "strings"
+
+type builderWithUpdate struct {
+	renderer
+	table       Table
+	assignments []*Assignment
+	fromTable   Table
+	joins       []*joinDef
+	where       Condition
+	returning   []Column
+	with        withClause
+}
+
+var (
+	_ BuilderWithUpdate      = &builderWithUpdate{}
+	_ BuilderWithUpdateSet   = &builderWithUpdate{}
+	_ BuilderWithUpdateFrom  = &builderWithUpdate{}
+	_ BuilderWithUpdateJoin  = &builderWithUpdate{}
+	_ BuilderWithUpdateWhere = &builderWithUpdate{}
+)
+
+func newBuilderWithUpdate(t Table) *builderWithUpdate {
+	b := &builderWithUpdate{table: t}
+	b.query = b
+	return b
+}
+
+func (b *builderWithUpdate) Set(first *Assignment, assignments ...*Assignment) BuilderWithUpdateSet {
+	assignments = append([]*Assignment{first}, assignments...)
+	checkAssignments("Update", b.table, len(b.assignments), assignments)
+	b.assignments = append(b.assignments, assignments...)
+	return b
+}
+
+func (b *builderWithUpdate) From(t Table) BuilderWithUpdateFrom {
+	b.fromTable = t
+	b.with.register(t)
+	return b
+}
+
+func (b *builderWithUpdate) Join(t Table) BuilderWithUpdateJoin {
+	b.joins = append(b.joins, newJoinDef(InnerJoin, t, nil))
+	b.with.register(t)
+	return b
+}
+
+func (b *builderWithUpdate) LeftJoin(t Table) BuilderWithUpdateJoin {
+	b.joins = append(b.joins, newJoinDef(LeftJoin, t, nil))
+	b.with.register(t)
+	return b
+}
+
+func (b *builderWithUpdate) On(condition Condition) BuilderWithUpdateFrom {
+	lastJoin := b.joins[len(b.joins)-1]
+	lastJoin.joinCondition = condition
+	return b
+}
+
+func (b *builderWithUpdate) Where(cond Condition) BuilderWithUpdateWhere {
+	b.where = cond
+	return b
+}
+
+func (b *builderWithUpdate) Returning(first Column, columns ...Column) SQLable {
+	b.returning = append([]Column{first}, columns...)
+	return b
+}
+
+func (b *builderWithUpdate) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	var withSql string
+	withSql, params = b.with.SqlWithParams(params, DefinitionContext)
+	var tableSql string
+	tableSql, params = b.table.SqlWithParams(params, DefinitionContext)
+
+	setSql := make([]string, len(b.assignments))
+	for i, assignment := range b.assignments {
+		setSql[i], params = assignment.SqlWithParams(params, ReferenceContext)
+	}
+	out := withSql + "UPDATE " + tableSql + " SET " + strings.Join(setSql, ", ")
+
+	if b.fromTable != nil {
+		requireFeature(params.Dialect(), FeatureUpdateFrom)
+		var fromSql string
+		fromSql, params = b.fromTable.SqlWithParams(params, DefinitionContext)
+		out += " FROM " + fromSql
+		for _, join := range b.joins {
+			var joinSql string
+			joinSql, params = join.SqlWithParams(params, DefinitionContext)
+			out += " " + joinSql
+		}
+	}
+
+	if b.where != nil {
+		out += " WHERE " + b.where.SQL(params)
+	}
+
+	var returningSql string
+	returningSql, params = renderReturning(b.returning, params)
+	return out + returningSql, params
+}
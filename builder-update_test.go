@@ -0,0 +1,110 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	t.Run("set params", func(t *testing.T) {
+		sql, params := Update(Account).
+			Set(Account.Uuid.SetParam("u1"), Account.CreatedTs.SetParam(10)).
+			SQL()
+
+		require.Equal(t, "UPDATE account SET uuid = ?, created_ts = ?", sql)
+		require.Equal(t, []any{"u1", 10}, params)
+	})
+
+	t.Run("set expressions", func(t *testing.T) {
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.Set(Config.CreatedTs)).
+			Set(Config.Uuid.Set(Upper(Config.Uuid))).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET archived_ts = config.created_ts, uuid = UPPER(config.uuid)", sql)
+		require.Empty(t, params)
+	})
+
+	t.Run("set null", func(t *testing.T) {
+		sql, _ := Update(Config).Set(Config.ArchivedTs.SetNull()).SQL()
+		require.Equal(t, "UPDATE config SET archived_ts = NULL", sql)
+	})
+
+	t.Run("where", func(t *testing.T) {
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.SetParam(100)).
+			Where(Config.Id.EqParam(int64(7)).And(Config.ArchivedTs.IsNull())).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET archived_ts = ? WHERE config.id = ? AND config.archived_ts IS NULL", sql)
+		require.Equal(t, []any{100, int64(7)}, params)
+	})
+
+	t.Run("aliased table", func(t *testing.T) {
+		c := Config.As("c")
+		sql, _ := Update(c).
+			Set(c.ArchivedTs.Set(c.CreatedTs)).
+			Where(c.Id.Eq(c.AccountId)).
+			SQL()
+
+		require.Equal(t, "UPDATE config AS c SET archived_ts = c.created_ts WHERE c.id = c.account_id", sql)
+	})
+
+	t.Run("from with joins", func(t *testing.T) {
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.Set(ShoppingCart.ArchivedTs)).
+			From(Account).
+			Join(ShoppingCart).On(ShoppingCart.OwnerId.Eq(Account.Id)).
+			LeftJoin(Config.As("c2")).On(Config.As("c2").AccountId.Eq(Account.Id)).
+			Where(Config.AccountId.Eq(Account.Id).And(Account.Type.EqParam("vip"))).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET archived_ts = shopping_cart.archived_ts "+
+			"FROM account "+
+			"JOIN shopping_cart ON shopping_cart.owner_id = account.id "+
+			"LEFT JOIN config AS c2 ON c2.account_id = account.id "+
+			"WHERE config.account_id = account.id AND account.type = ?", sql)
+		require.Equal(t, []any{"vip"}, params)
+	})
+
+	t.Run("from subquery with params", func(t *testing.T) {
+		sub := Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip")).AsNamedSubQuery("vip")
+		vipId := NewCol[int64]("id", sub)
+
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.SetParam(1)).
+			From(sub).
+			Where(Config.AccountId.Eq(vipId).And(Config.Uuid.EqParam("u1"))).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET archived_ts = ? "+
+			"FROM (SELECT account.id FROM account WHERE account.type = ?) AS vip "+
+			"WHERE config.account_id = vip.id AND config.uuid = ?", sql)
+		require.Equal(t, []any{1, "vip", "u1"}, params)
+	})
+}
+
+func TestUpdate_InvalidAssignments(t *testing.T) {
+	t.Run("column of another table", func(t *testing.T) {
+		require.PanicsWithValue(t, "Update: assignment 1 is for column owner_id of another table, expected a column of account", func() {
+			Update(Account).Set(ShoppingCart.OwnerId.SetParam(1))
+		})
+	})
+
+	t.Run("column of another alias", func(t *testing.T) {
+		require.PanicsWithValue(t, "Update: assignment 2 is for column type of another table, expected a column of account", func() {
+			Update(Account).Set(Account.Uuid.SetParam("u1")).Set(Account.As("a").Type.SetParam("vip"))
+		})
+	})
+
+	t.Run("upsert column of another table", func(t *testing.T) {
+		require.PanicsWithValue(t, "DoUpdateSet: assignment 2 is for column uuid of another table, expected a column of account", func() {
+			InsertInto(Account).
+				Columns(Account.Uuid).
+				Values(Account.Uuid.Value("u1")).
+				OnConflict(Account.Uuid).
+				DoUpdateSet(Account.Type.SetParam("vip"), Config.Uuid.Set(Account.Uuid.Excluded()))
+		})
+	})
+}
@@ -1,9 +1,9 @@
 package tomasql
 
 type builderWithFrom struct {
+	renderer
 	prevStage ParametricSql
 	fromTable ParametricSql
-	params    ParamsMap
 }
 
 var _ BuilderWithTables = &builderWithFrom{}
@@ -13,10 +13,18 @@ func newBuilderWithFrom(prev, from ParametricSql) BuilderWithTables {
 		prevStage: prev,
 		fromTable: from,
 	}
+	b.query = b
+	if t, ok := from.(Table); ok {
+		registerCTE(prev, t)
+	}
 
 	return b
 }
 
+func (b *builderWithFrom) previousStage() ParametricSql {
+	return b.prevStage
+}
+
 func (b *builderWithFrom) Joins(joinItems ...*JoinItem) BuilderWithTables {
 	return _addJoins(b, joinItems...)
 }
@@ -46,6 +54,22 @@ func (b *builderWithFrom) RightJoin(t Table) BuilderWithJoin {
 	return newBuilderWithJoin(b, RightJoin, t)
 }
 
+func (b *builderWithFrom) FullJoin(t Table) BuilderWithJoin {
+	return newBuilderWithJoin(b, FullJoin, t)
+}
+
+func (b *builderWithFrom) CrossJoin(t Table) BuilderWithTables {
+	return newBuilderWithJoin(b, CrossJoin, t).(*builderWithJoin)
+}
+
+func (b *builderWithFrom) JoinLateral(t Table) BuilderWithJoin {
+	return newBuilderWithJoin(b, InnerJoin, nil).(*builderWithJoin)._joinLateral(InnerJoin, t)
+}
+
+func (b *builderWithFrom) LeftJoinLateral(t Table) BuilderWithJoin {
+	return newBuilderWithJoin(b, InnerJoin, nil).(*builderWithJoin)._joinLateral(LeftJoin, t)
+}
+
 func (b *builderWithFrom) Where(cond Condition) BuilderWithWhere {
 	withJoin := (b.Join(nil)).(*builderWithJoin)
 	return newBuilderWithWhere(withJoin, cond)
@@ -55,6 +79,10 @@ func (b *builderWithFrom) GroupBy(column ParametricSql, columns ...ParametricSql
 	return newBuilderWithGroupBy(b, append([]ParametricSql{column}, columns...), nil)
 }
 
+func (b *builderWithFrom) Window(window *WindowDef, windows ...*WindowDef) BuilderWithWindow {
+	return newBuilderWithWindow(b, append([]*WindowDef{window}, windows...))
+}
+
 func (b *builderWithFrom) OrderBy(column SortColumn, columns ...SortColumn) BuilderWithOrderBy {
 	return newBuilderWithOrderBy(b, append([]SortColumn{column}, columns...))
 }
@@ -67,16 +95,26 @@ func (b *builderWithFrom) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
 
-func (b *builderWithFrom) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
-	b.params = params.AddAll(b.params)
-	var sql string
-	sql, b.params = b.prevStage.SqlWithParams(b.params, ctx)
-	var sqlTable string
-	sqlTable, b.params = b.fromTable.SqlWithParams(b.params, DefinitionContext)
-	return sql + " FROM " + sqlTable, b.params
+func (b *builderWithFrom) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
 }
 
-func (b *builderWithFrom) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+func (b *builderWithFrom) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithFrom) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithFrom) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
+func (b *builderWithFrom) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	var sql string
+	sql, params = b.prevStage.SqlWithParams(params, ctx)
+	var sqlTable string
+	sqlTable, params = b.fromTable.SqlWithParams(params, DefinitionContext)
+	return sql + " FROM " + sqlTable, params
 }
@@ -3,10 +3,10 @@ package tomasql
 import "strings"
 
 type builderWithGroupBy struct {
+	renderer
 	prevStage ParametricSql
 	groupBy   []ParametricSql
 	having    Condition
-	params    ParamsMap
 }
 
 var _ BuilderWithGroupBy = &builderWithGroupBy{}
@@ -16,8 +16,8 @@ func newBuilderWithGroupBy(prev ParametricSql, groupBy []ParametricSql, having C
 		prevStage: prev,
 		groupBy:   groupBy,
 		having:    having,
-		params:    ParamsMap{},
 	}
+	b.query = b
 	return b
 }
 
@@ -26,30 +26,32 @@ func (b *builderWithGroupBy) Having(condition Condition) BuilderWithHaving {
 	return b
 }
 
-func (b *builderWithGroupBy) OrderBy(first SortColumn, columns ...SortColumn) BuilderWithOrderBy {
-	return newBuilderWithOrderBy(b, append([]SortColumn{first}, columns...))
+func (b *builderWithGroupBy) Window(window *WindowDef, windows ...*WindowDef) BuilderWithWindow {
+	return newBuilderWithWindow(b, append([]*WindowDef{window}, windows...))
 }
 
-func (b *builderWithGroupBy) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+func (b *builderWithGroupBy) OrderBy(first SortColumn, columns ...SortColumn) BuilderWithOrderBy {
+	return newBuilderWithOrderBy(b, append([]SortColumn{first}, columns...))
 }
 
-func (b *builderWithGroupBy) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
-	b.params = paramsMap.AddAll(b.params)
+func (b *builderWithGroupBy) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	var sql string
-	sql, b.params = b.prevStage.SqlWithParams(b.params, ctx)
+	sql, params = b.prevStage.SqlWithParams(params, ctx)
 	var groupBySql []string
 	for _, col := range b.groupBy {
 		var colSql string
-		colSql, b.params = col.SqlWithParams(b.params, ReferenceContext)
+		colSql, params = col.SqlWithParams(params, ReferenceContext)
 		groupBySql = append(groupBySql, colSql)
 	}
 	havingSql := ""
 	if b.having != nil {
-		havingSql = " HAVING " + b.having.SQL(b.params)
+		havingSql = " HAVING " + b.having.SQL(params)
 	}
-	return sql + " GROUP BY " + strings.Join(groupBySql, ", ") + havingSql, b.params
+	return sql + " GROUP BY " + strings.Join(groupBySql, ", ") + havingSql, params
+}
+
+func (b *builderWithGroupBy) previousStage() ParametricSql {
+	return b.prevStage
 }
 
 func (b *builderWithGroupBy) AsNamedSubQuery(alias string) Table {
@@ -59,3 +61,19 @@ func (b *builderWithGroupBy) AsNamedSubQuery(alias string) Table {
 func (b *builderWithGroupBy) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
+
+func (b *builderWithGroupBy) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithGroupBy) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithGroupBy) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithGroupBy) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
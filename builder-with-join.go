@@ -3,9 +3,9 @@ package tomasql
 import "strings"
 
 type builderWithJoin struct {
+	renderer
 	prevStage ParametricSql
 	joins     []*joinDef
-	params    ParamsMap
 }
 
 var (
@@ -14,19 +14,23 @@ var (
 )
 
 func newBuilderWithJoin(prev ParametricSql, joinType JoinType, joinTable Table) BuilderWithJoin {
-	params := ParamsMap{}
 	var joins []*joinDef
 	if joinTable != nil {
 		joins = append(joins, newJoinDef(joinType, joinTable, nil))
+		registerCTE(prev, joinTable)
 	}
 	b := &builderWithJoin{
 		prevStage: prev,
 		joins:     joins,
-		params:    params,
 	}
+	b.query = b
 	return b
 }
 
+func (b *builderWithJoin) previousStage() ParametricSql {
+	return b.prevStage
+}
+
 func (b *builderWithJoin) AsNamedSubQuery(alias string) Table {
 	return newWithOptionalAlias(b, &alias)
 }
@@ -35,19 +39,48 @@ func (b *builderWithJoin) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
 
+func (b *builderWithJoin) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithJoin) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithJoin) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithJoin) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
 func (b *builderWithJoin) On(condition Condition) BuilderWithTables {
 	lastJoin := b.joins[len(b.joins)-1]
 	lastJoin.joinCondition = condition
 	return b
 }
 
+func (b *builderWithJoin) Using(column Column, columns ...Column) BuilderWithTables {
+	lastJoin := b.joins[len(b.joins)-1]
+	lastJoin.usingColumns = append([]Column{column}, columns...)
+	return b
+}
+
 func (b *builderWithJoin) _join(joinType JoinType, t Table) BuilderWithJoin {
 	if t != nil {
 		b.joins = append(b.joins, newJoinDef(joinType, t, nil))
+		registerCTE(b, t)
 	}
 	return b
 }
 
+func (b *builderWithJoin) _joinLateral(joinType JoinType, t Table) BuilderWithJoin {
+	b._join(joinType, t)
+	b.joins[len(b.joins)-1].lateral = true
+	return b
+}
+
 func (b *builderWithJoin) Join(t Table) BuilderWithJoin {
 	return b._join(InnerJoin, t)
 }
@@ -60,6 +93,22 @@ func (b *builderWithJoin) RightJoin(t Table) BuilderWithJoin {
 	return b._join(RightJoin, t)
 }
 
+func (b *builderWithJoin) FullJoin(t Table) BuilderWithJoin {
+	return b._join(FullJoin, t)
+}
+
+func (b *builderWithJoin) CrossJoin(t Table) BuilderWithTables {
+	return b._join(CrossJoin, t).(*builderWithJoin)
+}
+
+func (b *builderWithJoin) JoinLateral(t Table) BuilderWithJoin {
+	return b._joinLateral(InnerJoin, t)
+}
+
+func (b *builderWithJoin) LeftJoinLateral(t Table) BuilderWithJoin {
+	return b._joinLateral(LeftJoin, t)
+}
+
 func (b *builderWithJoin) Joins(joinItems ...*JoinItem) BuilderWithTables {
 	return _addJoins(b, joinItems...)
 }
@@ -72,28 +121,26 @@ func (b *builderWithJoin) GroupBy(column ParametricSql, columns ...ParametricSql
 	return newBuilderWithGroupBy(b, append([]ParametricSql{column}, columns...), nil)
 }
 
+func (b *builderWithJoin) Window(window *WindowDef, windows ...*WindowDef) BuilderWithWindow {
+	return newBuilderWithWindow(b, append([]*WindowDef{window}, windows...))
+}
+
 func (b *builderWithJoin) OrderBy(column SortColumn, columns ...SortColumn) BuilderWithOrderBy {
 	return newBuilderWithOrderBy(b, append([]SortColumn{column}, columns...))
 }
 
-func (b *builderWithJoin) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
-	b.params = params.AddAll(b.params)
+func (b *builderWithJoin) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	var out string
-	out, b.params = b.prevStage.SqlWithParams(b.params, ctx)
+	out, params = b.prevStage.SqlWithParams(params, ctx)
 	if len(b.joins) > 0 {
 		var joinStr []string
 		for _, join := range b.joins {
 			var jstr string
-			jstr, b.params = join.SqlWithParams(b.params, DefinitionContext)
+			jstr, params = join.SqlWithParams(params, DefinitionContext)
 			joinStr = append(joinStr, jstr)
 		}
 		join := strings.Join(joinStr, " ")
 		out += " " + join
 	}
-	return out, b.params
-}
-
-func (b *builderWithJoin) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+	return out, params
 }
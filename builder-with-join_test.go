@@ -347,3 +347,96 @@ func TestBuilderWithJoin_Joins(t *testing.T) {
 		require.Equal(t, expected, sql)
 	})
 }
+
+func TestBuilderWithJoin_FullAndCrossJoin(t *testing.T) {
+	t.Run("full join", func(t *testing.T) {
+		sql, _ := Select(Account.Id, ShoppingCart.Id).From(Account).
+			FullJoin(ShoppingCart).On(Account.Id.Eq(ShoppingCart.OwnerId)).
+			SQL()
+
+		expected := "SELECT account.id, shopping_cart.id FROM account FULL JOIN shopping_cart ON account.id = shopping_cart.owner_id"
+		require.Equal(t, expected, sql)
+	})
+
+	t.Run("cross join", func(t *testing.T) {
+		sql, _ := Select(Account.Id, Config.Id).From(Account).
+			CrossJoin(Config).
+			Join(ShoppingCart).On(Account.Id.Eq(ShoppingCart.OwnerId)).
+			CrossJoin(Config.As("c2")).
+			SQL()
+
+		expected := "SELECT account.id, config.id FROM account CROSS JOIN config " +
+			"JOIN shopping_cart ON account.id = shopping_cart.owner_id CROSS JOIN config AS c2"
+		require.Equal(t, expected, sql)
+	})
+}
+
+func TestBuilderWithJoin_Using(t *testing.T) {
+	sql, _ := Select(Config.Id).From(Config).
+		Join(ShoppingCart).Using(ShoppingCart.Uuid).
+		LeftJoin(Account).Using(Account.Uuid, Account.CreatedTs).
+		SQL()
+
+	expected := "SELECT config.id FROM config JOIN shopping_cart USING (uuid) " +
+		"LEFT JOIN account USING (uuid, created_ts)"
+	require.Equal(t, expected, sql)
+}
+
+func TestBuilderWithJoin_Lateral(t *testing.T) {
+	t.Run("latest rows per group", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureLateral}})
+
+		latest := Select(ShoppingCart.Id, ShoppingCart.CreatedTs).From(ShoppingCart).
+			Where(ShoppingCart.OwnerId.Eq(Account.Id).And(ShoppingCart.ArchivedTs.IsNull())).
+			OrderBy(ShoppingCart.CreatedTs.Desc()).
+			Limit(3).
+			AsNamedSubQuery("latest")
+
+		sql, params := Select(Account.Id, DerivedCol(latest, ShoppingCart.Id)).From(Account).
+			JoinLateral(latest).On(IdentityCond).
+			Where(Account.Type.EqParam("user")).
+			SQL()
+
+		expected := "SELECT account.id, latest.id FROM account " +
+			"JOIN LATERAL (SELECT shopping_cart.id, shopping_cart.created_ts FROM shopping_cart " +
+			"WHERE shopping_cart.owner_id = account.id AND shopping_cart.archived_ts IS NULL " +
+			"ORDER BY shopping_cart.created_ts DESC LIMIT 3) AS latest ON 1 = 1 " +
+			"WHERE account.type = $1"
+		require.Equal(t, expected, sql)
+		require.Equal(t, []any{"user"}, params)
+	})
+
+	t.Run("left join lateral after other joins", func(t *testing.T) {
+		total := Select(Count().As("total")).From(ShoppingCart).
+			Where(ShoppingCart.OwnerId.Eq(Account.Id)).
+			AsNamedSubQuery("carts")
+
+		sql, _ := Select(Account.Id).From(Account).
+			Join(Config).On(Config.AccountId.Eq(Account.Id)).
+			LeftJoinLateral(total).On(IdentityCond).
+			SQL()
+
+		expected := "SELECT account.id FROM account JOIN config ON config.account_id = account.id " +
+			"LEFT JOIN LATERAL (SELECT COUNT(1) AS total FROM shopping_cart WHERE shopping_cart.owner_id = account.id) AS carts ON 1 = 1"
+		require.Equal(t, expected, sql)
+	})
+}
+
+func TestBuilderWithJoin_UnsupportedJoins(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureRightJoin}})
+
+	sql, _ := Select(Account.Id).From(Account).RightJoin(Config).On(Config.AccountId.Eq(Account.Id)).SQL()
+	require.Equal(t, "SELECT account.id FROM account RIGHT JOIN config ON config.account_id = account.id", sql)
+
+	fullJoin := Select(Account.Id).From(Account).FullJoin(Config).On(Config.AccountId.Eq(Account.Id))
+	require.PanicsWithError(t, "tomasql: FULL JOIN is not supported by the numbered dialect", func() {
+		fullJoin.SQL()
+	})
+
+	lateral := Select(Account.Id).From(Account).
+		JoinLateral(Select(Config.Id).From(Config).Where(Config.AccountId.Eq(Account.Id)).AsNamedSubQuery("c")).
+		On(IdentityCond)
+	require.PanicsWithError(t, "tomasql: LATERAL is not supported by the numbered dialect", func() {
+		lateral.SQL()
+	})
+}
@@ -0,0 +1,145 @@
+package tomasql
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrLockWithAggregation is the panic value used when rendering a row locking clause in a query whose rows do not
+// map to table rows: a query with GROUP BY, HAVING, DISTINCT, a WINDOW clause or aggregate or window functions.
+var ErrLockWithAggregation = errors.New("tomasql: row locking is not allowed with GROUP BY, HAVING, DISTINCT, " +
+	"WINDOW or aggregate functions")
+
+type lockStrength string
+
+const (
+	lockForUpdate      lockStrength = "FOR UPDATE"
+	lockForNoKeyUpdate lockStrength = "FOR NO KEY UPDATE"
+	lockForShare       lockStrength = "FOR SHARE"
+)
+
+func (s lockStrength) feature() Feature {
+	switch s {
+	case lockForNoKeyUpdate:
+		return FeatureForNoKeyUpdate
+	case lockForShare:
+		return FeatureForShare
+	default:
+		return FeatureForUpdate
+	}
+}
+
+type lockWaitPolicy string
+
+const (
+	lockWait       lockWaitPolicy = ""
+	lockSkipLocked lockWaitPolicy = "SKIP LOCKED"
+	lockNoWait     lockWaitPolicy = "NOWAIT"
+)
+
+// builderWithLock renders the row locking clause of a SELECT, e.g. FOR UPDATE OF account SKIP LOCKED.
+type builderWithLock struct {
+	renderer
+	prevStage ParametricSql
+	strength  lockStrength
+	of        []Table
+	wait      lockWaitPolicy
+}
+
+var _ BuilderWithLock = &builderWithLock{}
+
+func newBuilderWithLock(prev ParametricSql, strength lockStrength) BuilderWithLock {
+	b := &builderWithLock{
+		prevStage: prev,
+		strength:  strength,
+	}
+	b.query = b
+	return b
+}
+
+func (b *builderWithLock) previousStage() ParametricSql {
+	return b.prevStage
+}
+
+func (b *builderWithLock) AsNamedSubQuery(alias string) Table {
+	return newWithOptionalAlias(b, &alias)
+}
+
+func (b *builderWithLock) AsSubQuery() SQLable {
+	return newWithOptionalAlias(b, nil)
+}
+
+func (b *builderWithLock) Of(table Table, tables ...Table) BuilderWithLock {
+	b.of = append([]Table{table}, tables...)
+	return b
+}
+
+func (b *builderWithLock) SkipLocked() LockedQuery {
+	b.wait = lockSkipLocked
+	return b
+}
+
+func (b *builderWithLock) NoWait() LockedQuery {
+	b.wait = lockNoWait
+	return b
+}
+
+func (b *builderWithLock) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	requireFeature(params.Dialect(), b.strength.feature())
+	switch b.wait {
+	case lockSkipLocked:
+		requireFeature(params.Dialect(), FeatureSkipLocked)
+	case lockNoWait:
+		requireFeature(params.Dialect(), FeatureNoWait)
+	}
+
+	if aggregatesRows(b.prevStage) {
+		panic(ErrLockWithAggregation)
+	}
+
+	var sql string
+	sql, params = b.prevStage.SqlWithParams(params, ctx)
+	sql += " " + string(b.strength)
+	if len(b.of) > 0 {
+		names := make([]string, len(b.of))
+		for i, t := range b.of {
+			// locked tables are referenced by the name they have in the FROM clause
+			names[i] = quoteIdent(params.Dialect(), t.TableName())
+			if t.Alias() != nil {
+				names[i] = quoteIdent(params.Dialect(), *t.Alias())
+			}
+		}
+		sql += " OF " + strings.Join(names, ", ")
+	}
+	if b.wait != lockWait {
+		sql += " " + string(b.wait)
+	}
+	return sql, params
+}
+
+// aggregatesRows reports whether the query of stage combines table rows into its result rows, which cannot be locked.
+func aggregatesRows(stage ParametricSql) bool {
+	for {
+		switch s := stage.(type) {
+		case *builderWithGroupBy, *builderWithWindow:
+			return true
+		case *builderWithSelectAll:
+			return s.distinct || s.modifier != nil
+		case *builderWithSelect:
+			// the only modifier is DISTINCT ON
+			if s.distinct || s.modifier != nil {
+				return true
+			}
+			for _, item := range s.selectColumns {
+				if _, ok := item.(rowsAggregator); ok {
+					return true
+				}
+			}
+			return false
+		case chainedStage:
+			stage = s.previousStage()
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package tomasql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderWithLock(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{
+		FeatureForUpdate, FeatureForNoKeyUpdate, FeatureForShare, FeatureSkipLocked, FeatureNoWait,
+	}})
+
+	t.Run("job queue", func(t *testing.T) {
+		sql, params := Select(ShoppingCart.Id).From(ShoppingCart).
+			Where(ShoppingCart.ArchivedTs.IsNull().And(ShoppingCart.OwnerId.EqParam(int64(1)))).
+			OrderBy(ShoppingCart.CreatedTs.Asc()).
+			Limit(10).
+			ForUpdate().SkipLocked().
+			SQL()
+
+		require.Equal(t, "SELECT shopping_cart.id FROM shopping_cart "+
+			"WHERE shopping_cart.archived_ts IS NULL AND shopping_cart.owner_id = $1 "+
+			"ORDER BY shopping_cart.created_ts ASC LIMIT 10 FOR UPDATE SKIP LOCKED", sql)
+		require.Equal(t, []any{int64(1)}, params)
+	})
+
+	t.Run("after where", func(t *testing.T) {
+		sql, _ := Select(Account.Id).From(Account).Where(Account.Type.EqParam("admin")).ForShare().SQL()
+		require.Equal(t, "SELECT account.id FROM account WHERE account.type = $1 FOR SHARE", sql)
+	})
+
+	t.Run("after order by", func(t *testing.T) {
+		sql, _ := Select(Account.Id).From(Account).Where(IdentityCond).
+			OrderBy(Account.Id.Asc()).
+			ForNoKeyUpdate().NoWait().
+			SQL()
+		require.Equal(t, "SELECT account.id FROM account WHERE 1 = 1 ORDER BY account.id ASC FOR NO KEY UPDATE NOWAIT", sql)
+	})
+
+	t.Run("of tables", func(t *testing.T) {
+		carts := ShoppingCart.As("sc")
+		sql, _ := Select(Account.Id, carts.Id).From(Account).
+			Join(carts).On(carts.OwnerId.Eq(Account.Id)).
+			Where(Account.Type.EqParam("user")).
+			ForUpdate().Of(Account, carts).NoWait().
+			SQL()
+		require.Equal(t, "SELECT account.id, sc.id FROM account JOIN shopping_cart AS sc ON sc.owner_id = account.id "+
+			"WHERE account.type = $1 FOR UPDATE OF account, sc NOWAIT", sql)
+	})
+
+	t.Run("as subquery", func(t *testing.T) {
+		next := Select(ShoppingCart.Id).From(ShoppingCart).
+			Where(ShoppingCart.ArchivedTs.IsNull()).
+			OrderBy(ShoppingCart.CreatedTs.Asc()).
+			Limit(1).
+			ForUpdate().SkipLocked()
+
+		sql, params := DeleteFrom(ShoppingCart).
+			Where(ShoppingCart.Id.In(next.AsSubQuery()).And(ShoppingCart.OwnerId.EqParam(int64(2)))).
+			SQL()
+		require.Equal(t, "DELETE FROM shopping_cart WHERE shopping_cart.id IN "+
+			"(SELECT shopping_cart.id FROM shopping_cart WHERE shopping_cart.archived_ts IS NULL ORDER BY shopping_cart.created_ts ASC LIMIT 1 FOR UPDATE SKIP LOCKED) "+
+			"AND shopping_cart.owner_id = $1", sql)
+		require.Equal(t, []any{int64(2)}, params)
+	})
+}
+
+func TestBuilderWithLock_NoSetOperations(t *testing.T) {
+	for _, method := range []string{"Union", "UnionAll", "Intersect", "Except"} {
+		_, found := reflect.TypeFor[BuilderWithLock]().MethodByName(method)
+		require.False(t, found, "BuilderWithLock.%s", method)
+		_, found = reflect.TypeFor[LockedQuery]().MethodByName(method)
+		require.False(t, found, "LockedQuery.%s", method)
+	}
+}
+
+func TestBuilderWithLock_UnsupportedFeature(t *testing.T) {
+	t.Run("locking", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		query := Select(Account.Id).From(Account).Where(IdentityCond).ForUpdate()
+		require.PanicsWithError(t, "tomasql: FOR UPDATE is not supported by the numbered dialect", func() {
+			query.SQL()
+		})
+	})
+
+	t.Run("wait policy", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureForUpdate}})
+
+		query := Select(Account.Id).From(Account).Where(IdentityCond).ForUpdate().SkipLocked()
+		require.PanicsWithError(t, "tomasql: SKIP LOCKED is not supported by the numbered dialect", func() {
+			query.SQL()
+		})
+	})
+}
+
+func TestBuilderWithLock_Aggregation(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureForUpdate, FeatureForShare}})
+
+	tests := []struct {
+		name  string
+		query LockedQuery
+	}{
+		{"group by", Select(Account.Type).From(Account).GroupBy(Account.Type).OrderBy(Account.Type.Asc()).ForUpdate()},
+		{"having", Select(Account.Type).From(Account).GroupBy(Account.Type).Having(Account.Type.NeqParam("guest")).
+			OrderBy(Account.Type.Asc()).Limit(1).ForShare()},
+		{"window", Select(RowNumber().Over(Window().As("w"))).From(Account).Window(Window().As("w")).
+			OrderBy(Account.Id.Asc()).ForUpdate()},
+		{"distinct", SelectDistinct(Account.Type).From(Account).Where(IdentityCond).ForUpdate()},
+		{"distinct select all", SelectDistinctAll().From(Account).Where(IdentityCond).ForUpdate()},
+		{"aggregate", Select(Count()).From(Account).Where(IdentityCond).ForUpdate()},
+		{"aliased aggregate", Select(Sum[int](Account.CreatedTs).As("total")).From(Account).Where(IdentityCond).ForShare()},
+		{"window function", Select(Account.Id, RowNumber().Over(Window().OrderBy(Account.Id.Asc()))).From(Account).
+			Where(IdentityCond).ForUpdate()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.query.Build()
+			require.ErrorIs(t, err, ErrLockWithAggregation)
+		})
+	}
+}
@@ -1,16 +1,13 @@
 package tomasql
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 type builderWithOrderBy struct {
+	renderer
 	prevStage ParametricSql
 	orderBy   []SortColumn
 	limit     *int
 	offset    *int
-	params    ParamsMap
 }
 
 var (
@@ -18,16 +15,20 @@ var (
 	_ BuilderWithLimit   = &builderWithOrderBy{}
 )
 
-func newBuilderWithOrderBy(prev ParametricSql, orderBy []SortColumn) BuilderWithOrderBy {
+func newBuilderWithOrderBy(prev ParametricSql, orderBy []SortColumn) *builderWithOrderBy {
 	b := &builderWithOrderBy{
 		prevStage: prev,
 		orderBy:   orderBy,
-		params:    ParamsMap{},
 	}
+	b.query = b
 
 	return b
 }
 
+func (b *builderWithOrderBy) previousStage() ParametricSql {
+	return b.prevStage
+}
+
 func (b *builderWithOrderBy) AsNamedSubQuery(alias string) Table {
 	return newWithOptionalAlias(b, &alias)
 }
@@ -36,6 +37,22 @@ func (b *builderWithOrderBy) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
 
+func (b *builderWithOrderBy) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithOrderBy) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithOrderBy) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithOrderBy) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
 func (b *builderWithOrderBy) Limit(i int) BuilderWithLimit {
 	b.limit = &i
 	return b
@@ -46,30 +63,86 @@ func (b *builderWithOrderBy) Offset(i int) SQLable {
 	return b
 }
 
-func (b *builderWithOrderBy) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
-	b.params = params.AddAll(b.params)
+func (b *builderWithOrderBy) ForUpdate() BuilderWithLock {
+	return newBuilderWithLock(b, lockForUpdate)
+}
+
+func (b *builderWithOrderBy) ForNoKeyUpdate() BuilderWithLock {
+	return newBuilderWithLock(b, lockForNoKeyUpdate)
+}
+
+func (b *builderWithOrderBy) ForShare() BuilderWithLock {
+	return newBuilderWithLock(b, lockForShare)
+}
+
+func (b *builderWithOrderBy) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	d := params.Dialect()
+	if err := b.validateOrderBy(d); err != nil {
+		panic(err)
+	}
+
+	limitSql := ""
+	if b.limit != nil || b.offset != nil {
+		limitSql = " " + d.LimitOffset(b.limit, b.offset)
+	}
+	if selectStage := b.selectStage(); b.limit != nil && b.offset == nil && selectStage != nil {
+		if top, ok := d.Top(*b.limit); ok {
+			// the limit is rendered by the SELECT stage, right after SELECT [DISTINCT]
+			limitSql = ""
+			params.setTop(selectStage, top)
+		}
+	}
+	if limitSql != "" && len(b.orderBy) == 0 {
+		requireFeature(d, FeatureUnorderedLimit)
+	}
+
 	var out string
-	out, b.params = b.prevStage.SqlWithParams(b.params, ctx)
+	out, params = b.prevStage.SqlWithParams(params, ctx)
 	if len(b.orderBy) > 0 {
 		out += " ORDER BY "
 		var orderStr []string
 		for _, col := range b.orderBy {
 			var sortStr string
-			sortStr, b.params = col.SqlWithParams(b.params, OrderByContext)
+			sortStr, params = col.SqlWithParams(params, OrderByContext)
 			orderStr = append(orderStr, sortStr)
 		}
 		out += strings.Join(orderStr, ", ")
 	}
-	if b.limit != nil {
-		out += fmt.Sprintf(" LIMIT %d", *b.limit)
+	return out + limitSql, params
+}
+
+// validateOrderBy lets the select modifier of the query, if any, check the ORDER BY clause rendered in dialect d.
+func (b *builderWithOrderBy) validateOrderBy(d Dialect) error {
+	selectStage := b.selectStage()
+	if len(b.orderBy) == 0 || selectStage == nil || selectStage.modifier == nil {
+		return nil
 	}
-	if b.offset != nil {
-		out += fmt.Sprintf(" OFFSET %d", *b.offset)
+	sortExprs := make([]ParametricSql, len(b.orderBy))
+	for i, col := range b.orderBy {
+		sortExprs[i] = col
+		if sorted, ok := col.(sortTerm); ok {
+			sortExprs[i] = sorted.sortExpression()
+		}
 	}
-	return out, b.params
+	return selectStage.modifier.ValidateOrderBy(d, sortExprs)
 }
 
-func (b *builderWithOrderBy) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+// selectStage returns the SELECT stage of the query, or nil if the ORDER BY and LIMIT apply to the result of a set
+// operation rather than to the rows of a single SELECT.
+func (b *builderWithOrderBy) selectStage() *builderWithSelect {
+	var stage ParametricSql = b.prevStage
+	for {
+		switch s := stage.(type) {
+		case *builderWithSetOperation:
+			return nil
+		case *builderWithSelect:
+			return s
+		case *builderWithSelectAll:
+			return s.builderWithSelect
+		case chainedStage:
+			stage = s.previousStage()
+		default:
+			return nil
+		}
+	}
 }
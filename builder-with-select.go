@@ -3,9 +3,11 @@ package tomasql
 import "strings"
 
 type builderWithSelect struct {
+	renderer
 	selectColumns []ParametricSql
 	distinct      bool
-	params        ParamsMap
+	modifier      SelectModifier
+	with          withClause
 }
 
 var _ BuilderWithSelect = &builderWithSelect{}
@@ -14,8 +16,8 @@ func newBuilderWithSelect(distinct bool, first ParametricSql, columns ...Paramet
 	b := &builderWithSelect{
 		selectColumns: append([]ParametricSql{first}, columns...),
 		distinct:      distinct,
-		params:        ParamsMap{},
 	}
+	b.query = b
 	return b
 }
 
@@ -27,57 +29,91 @@ func (b *builderWithSelect) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
 
+func (b *builderWithSelect) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithSelect) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithSelect) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithSelect) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
 func (b *builderWithSelect) From(t Table) BuilderWithTables {
 	return newBuilderWithFrom(b, t)
 }
 
-func (b *builderWithSelect) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (b *builderWithSelect) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	top := params.takeTop(b)
 	var colStr []string
-	b.params = params.AddAll(b.params)
+	var withStr string
+	withStr, params = b.with.SqlWithParams(params, ctx)
 	for _, col := range b.selectColumns {
 		var sql string
-		sql, b.params = col.SqlWithParams(b.params, DefinitionContext)
+		sql, params = col.SqlWithParams(params, DefinitionContext)
 		colStr = append(colStr, sql)
 	}
 	distinctStr := ""
 	if b.distinct {
 		distinctStr = "DISTINCT "
 	}
-	return "SELECT " + distinctStr + strings.Join(colStr, ", "), b.params
-}
-
-func (b *builderWithSelect) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+	if b.modifier != nil {
+		distinctStr, params = b.modifier.SqlWithParams(params, ctx)
+		distinctStr += " "
+	}
+	return withStr + "SELECT " + distinctStr + top + strings.Join(colStr, ", "), params
 }
 
 type builderWithSelectAll struct {
+	renderer
 	*builderWithSelect
 }
 
 var _ BuilderWithSelect = &builderWithSelectAll{}
 
 func newBuilderWithSelectAll(distinct bool) BuilderWithSelect {
-	return &builderWithSelectAll{
+	b := &builderWithSelectAll{
 		builderWithSelect: &builderWithSelect{distinct: distinct},
 	}
+	b.query = b
+	return b
 }
 
 func (b *builderWithSelectAll) From(t Table) BuilderWithTables {
 	return newBuilderWithFrom(b, t)
 }
 
-func (b *builderWithSelectAll) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (b *builderWithSelectAll) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithSelectAll) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithSelectAll) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithSelectAll) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
+func (b *builderWithSelectAll) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	top := params.takeTop(b.builderWithSelect)
+	var withStr string
+	withStr, params = b.with.SqlWithParams(params, ctx)
 	distinctStr := ""
 	if b.distinct {
 		distinctStr = "DISTINCT "
 	}
-	return "SELECT " + distinctStr + "*", params
-}
-
-func (b *builderWithSelectAll) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+	return withStr + "SELECT " + distinctStr + top + "*", params
 }
 
 type withOptionalAlias struct {
@@ -109,11 +145,11 @@ func (b *withOptionalAlias) As(alias *string) SQLable {
 	return b
 }
 
-func (b *withOptionalAlias) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (b *withOptionalAlias) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	sql, params := b.SQLable.SqlWithParams(params, ctx)
 	if b.alias == nil {
 		return "(" + sql + ")", params
 	}
 	// Subquery aliases should always be rendered (they're table aliases, not column aliases)
-	return "(" + sql + ") AS " + *b.alias, params
+	return "(" + sql + ") AS " + quoteIdent(params.Dialect(), *b.alias), params
 }
@@ -1,6 +1,8 @@
 package tomasql
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -287,3 +289,57 @@ func TestBuilderWithSelect_EdgeCases(t *testing.T) {
 		require.Equal(t, expected, sql)
 	})
 }
+
+// topModifier is a test SelectModifier that renders TOP n and refuses to sort by anything but account.id.
+type topModifier struct {
+	n         int
+	validated [][]ParametricSql
+}
+
+func (m *topModifier) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	return fmt.Sprintf("TOP %d", m.n), params
+}
+
+func (m *topModifier) ValidateOrderBy(d Dialect, sortExprs []ParametricSql) error {
+	m.validated = append(m.validated, sortExprs)
+	for _, expr := range sortExprs {
+		if sql, _ := expr.SqlWithParams(NewParamsMap(d), ReferenceContext); sql != "account.id" {
+			return errors.New("only account.id can be sorted")
+		}
+	}
+	return nil
+}
+
+func TestNewSelectWithModifier(t *testing.T) {
+	t.Run("renders the modifier", func(t *testing.T) {
+		modifier := &topModifier{n: 3}
+		sql, _ := NewSelectWithModifier(modifier, Account.Id, Account.Uuid).From(Account).
+			Where(IdentityCond).
+			OrderBy(Account.Id.Desc()).
+			SQL()
+
+		require.Equal(t, "SELECT TOP 3 account.id, account.uuid FROM account WHERE 1 = 1 ORDER BY account.id DESC", sql)
+		require.Len(t, modifier.validated, 1)
+	})
+
+	t.Run("order by is validated", func(t *testing.T) {
+		query := NewSelectWithModifier(&topModifier{n: 1}, Account.Id).From(Account).
+			Where(IdentityCond).
+			OrderBy(Account.Uuid.Asc())
+
+		require.PanicsWithError(t, "only account.id can be sorted", func() {
+			query.SQL()
+		})
+	})
+
+	t.Run("order by of a set operation is not validated", func(t *testing.T) {
+		modifier := &topModifier{n: 1}
+		sql, _ := NewSelectWithModifier(modifier, Account.Uuid).From(Account).
+			Union(Select(Config.Uuid).From(Config)).
+			OrderBy(Account.Uuid.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT TOP 1 account.uuid FROM account UNION SELECT config.uuid FROM config ORDER BY uuid ASC", sql)
+		require.Empty(t, modifier.validated)
+	})
+}
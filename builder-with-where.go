@@ -1,8 +1,8 @@
 package tomasql
 
 type builderWithWhere struct {
+	renderer
 	prevStage ParametricSql
-	params    ParamsMap
 	where     Condition
 }
 
@@ -13,9 +13,14 @@ func newBuilderWithWhere(prev *builderWithJoin, where Condition) BuilderWithWher
 		prevStage: prev,
 		where:     where,
 	}
+	b.query = b
 	return b
 }
 
+func (b *builderWithWhere) previousStage() ParametricSql {
+	return b.prevStage
+}
+
 func (b *builderWithWhere) AsNamedSubQuery(alias string) Table {
 	return newWithOptionalAlias(b, &alias)
 }
@@ -24,26 +29,52 @@ func (b *builderWithWhere) AsSubQuery() SQLable {
 	return newWithOptionalAlias(b, nil)
 }
 
+func (b *builderWithWhere) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithWhere) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithWhere) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithWhere) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
 func (b *builderWithWhere) GroupBy(column ParametricSql, columns ...ParametricSql) BuilderWithGroupBy {
 	return newBuilderWithGroupBy(b, append([]ParametricSql{column}, columns...), nil)
 }
 
+func (b *builderWithWhere) Window(window *WindowDef, windows ...*WindowDef) BuilderWithWindow {
+	return newBuilderWithWindow(b, append([]*WindowDef{window}, windows...))
+}
+
 func (b *builderWithWhere) OrderBy(column SortColumn, column2 ...SortColumn) BuilderWithOrderBy {
 	return newBuilderWithOrderBy(b, append([]SortColumn{column}, column2...))
 }
 
-func (b *builderWithWhere) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
-	b.params = params.AddAll(b.params)
+func (b *builderWithWhere) ForUpdate() BuilderWithLock {
+	return newBuilderWithLock(b, lockForUpdate)
+}
+
+func (b *builderWithWhere) ForNoKeyUpdate() BuilderWithLock {
+	return newBuilderWithLock(b, lockForNoKeyUpdate)
+}
+
+func (b *builderWithWhere) ForShare() BuilderWithLock {
+	return newBuilderWithLock(b, lockForShare)
+}
+
+func (b *builderWithWhere) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	var sql string
-	sql, b.params = b.prevStage.SqlWithParams(b.params, ctx)
+	sql, params = b.prevStage.SqlWithParams(params, ctx)
 	whereStr := ""
 	if b.where != nil {
-		whereStr = " WHERE " + b.where.SQL(b.params)
+		whereStr = " WHERE " + b.where.SQL(params)
 	}
-	return sql + whereStr, b.params
-}
-
-func (b *builderWithWhere) SQL() (sql string, params []any) {
-	sql, paramsMap := b.SqlWithParams(b.params, OutputContext)
-	return sql, paramsMap.ToSlice()
+	return sql + whereStr, params
 }
@@ -0,0 +1,69 @@
+package tomasql
+
+import "strings"
+
+type builderWithWindow struct {
+	renderer
+	prevStage ParametricSql
+	windows   []*WindowDef
+}
+
+var _ BuilderWithWindow = &builderWithWindow{}
+
+func newBuilderWithWindow(prev ParametricSql, windows []*WindowDef) BuilderWithWindow {
+	for _, w := range windows {
+		if w.name == nil {
+			panic("windows in a WINDOW clause must be named")
+		}
+	}
+	b := &builderWithWindow{
+		prevStage: prev,
+		windows:   windows,
+	}
+	b.query = b
+	return b
+}
+
+func (b *builderWithWindow) previousStage() ParametricSql {
+	return b.prevStage
+}
+
+func (b *builderWithWindow) AsNamedSubQuery(alias string) Table {
+	return newWithOptionalAlias(b, &alias)
+}
+
+func (b *builderWithWindow) AsSubQuery() SQLable {
+	return newWithOptionalAlias(b, nil)
+}
+
+func (b *builderWithWindow) Union(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionOperator, q)
+}
+
+func (b *builderWithWindow) UnionAll(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, unionAllOperator, q)
+}
+
+func (b *builderWithWindow) Intersect(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, intersectOperator, q)
+}
+
+func (b *builderWithWindow) Except(q SubQueryable) BuilderWithSetOperation {
+	return newBuilderWithSetOperation(b, exceptOperator, q)
+}
+
+func (b *builderWithWindow) OrderBy(column SortColumn, columns ...SortColumn) BuilderWithOrderBy {
+	return newBuilderWithOrderBy(b, append([]SortColumn{column}, columns...))
+}
+
+func (b *builderWithWindow) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	var sql string
+	sql, params = b.prevStage.SqlWithParams(params, ctx)
+	var windowSql []string
+	for _, w := range b.windows {
+		var defSql string
+		defSql, params = w.definitionSql(params)
+		windowSql = append(windowSql, defSql)
+	}
+	return sql + " WINDOW " + strings.Join(windowSql, ", "), params
+}
@@ -41,6 +41,28 @@ func SelectDistinct(first ParametricSql, column ...ParametricSql) BuilderWithSel
 	return newBuilderWithSelect(true, first, column...)
 }
 
+// NewSelectWithModifier starts a SELECT query whose select list is preceded by modifier. It is meant for dialect
+// extensions, e.g. pgres.SelectDistinctOn.
+func NewSelectWithModifier(modifier SelectModifier, first ParametricSql, columns ...ParametricSql) BuilderWithSelect {
+	b := newBuilderWithSelect(false, first, columns...).(*builderWithSelect)
+	b.modifier = modifier
+	return b
+}
+
+// With defines a common table expression named name. The returned table can be used in From/Join like any other table,
+// and DerivedCol can be used to reference its columns.
+func With(name string, query SubQueryable) *CTE {
+	return newCTE(name, query)
+}
+
+// WithRecursive defines a recursive common table expression named name, with anchor as its non-recursive term. The
+// recursive term is added with Union or UnionAll and can reference the CTE itself.
+func WithRecursive(name string, anchor SubQueryable) *RecursiveCTE {
+	cte := newCTE(name, anchor)
+	cte.recursive = &recursiveTerm{}
+	return &RecursiveCTE{CTE: cte}
+}
+
 func SelectAll() BuilderWithSelect {
 	return newBuilderWithSelectAll(false)
 }
@@ -48,3 +70,19 @@ func SelectAll() BuilderWithSelect {
 func SelectDistinctAll() BuilderWithSelect {
 	return newBuilderWithSelectAll(true)
 }
+
+// InsertInto starts an INSERT statement for the given table.
+func InsertInto(t Table) BuilderWithInsert {
+	return newBuilderWithInsert(t)
+}
+
+// Update starts an UPDATE statement for the given table.
+func Update(t Table) BuilderWithUpdate {
+	return newBuilderWithUpdate(t)
+}
+
+// DeleteFrom starts a DELETE statement for the given table. The statement can only be rendered after either a WHERE
+// condition or All() is provided, to avoid deleting every row by mistake.
+func DeleteFrom(t Table) BuilderWithDelete {
+	return newBuilderWithDelete(t)
+}
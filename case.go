@@ -0,0 +1,80 @@
+package tomasql
+
+import "strings"
+
+// CaseExpr is a searched CASE expression whose branches evaluate to T. It embeds FuncCol, so it can be compared,
+// aliased and sorted like any function column.
+type CaseExpr[T any] struct {
+	*FuncCol[T]
+	cases *caseSql
+}
+
+var (
+	_ FuncColumn      = &CaseExpr[int]{}
+	_ Expression[int] = &CaseExpr[int]{}
+)
+
+// Case starts a CASE expression. Add branches with When and an optional fallback with Else.
+func Case[T any]() *CaseExpr[T] {
+	cases := &caseSql{}
+	return &CaseExpr[T]{FuncCol: newExprCol[T](cases), cases: cases}
+}
+
+// When adds a WHEN cond THEN value branch.
+func (c *CaseExpr[T]) When(cond Condition, value Expression[T]) *CaseExpr[T] {
+	c.cases.whens = append(c.cases.whens, caseWhen{cond: cond, value: value})
+	return c
+}
+
+// WhenParam adds a WHEN cond THEN value branch where value is passed as a query parameter.
+func (c *CaseExpr[T]) WhenParam(cond Condition, value T) *CaseExpr[T] {
+	return c.When(cond, Param(value))
+}
+
+// Else sets the value of the expression when no branch matches. Without it, the expression is NULL in that case.
+func (c *CaseExpr[T]) Else(value Expression[T]) *CaseExpr[T] {
+	c.cases.elseValue = value
+	return c
+}
+
+// ElseParam sets the value of the expression when no branch matches, passed as a query parameter.
+func (c *CaseExpr[T]) ElseParam(value T) *CaseExpr[T] {
+	return c.Else(Param(value))
+}
+
+// As sets the alias of the expression. The returned column is the CASE expression itself.
+func (c *CaseExpr[T]) As(alias string) FuncColumn {
+	c.FuncCol.As(alias)
+	return c
+}
+
+type caseWhen struct {
+	cond  Condition
+	value ParametricSql
+}
+
+type caseSql struct {
+	whens     []caseWhen
+	elseValue ParametricSql
+}
+
+func (c *caseSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	if len(c.whens) == 0 {
+		panic("CASE expressions need at least one When branch")
+	}
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for _, when := range c.whens {
+		var valueSql string
+		condSql := when.cond.SQL(params)
+		valueSql, params = when.value.SqlWithParams(params, ReferenceContext)
+		sb.WriteString(" WHEN " + condSql + " THEN " + valueSql)
+	}
+	if c.elseValue != nil {
+		var elseSql string
+		elseSql, params = c.elseValue.SqlWithParams(params, ReferenceContext)
+		sb.WriteString(" ELSE " + elseSql)
+	}
+	sb.WriteString(" END")
+	return sb.String(), params
+}
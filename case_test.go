@@ -0,0 +1,97 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCase(t *testing.T) {
+	t.Run("literal branches", func(t *testing.T) {
+		c := Case[string]().
+			When(Account.Type.EqParam("admin"), Literal("staff")).
+			When(Account.Type.EqParam("user"), Literal("customer")).
+			Else(Literal("other"))
+
+		sql, params := Select(Account.Id, c.As("kind")).From(Account).SQL()
+
+		require.Equal(t, "SELECT account.id, CASE WHEN account.type = ? THEN 'staff' "+
+			"WHEN account.type = ? THEN 'customer' ELSE 'other' END AS kind FROM account", sql)
+		require.Equal(t, []any{"admin", "user"}, params)
+	})
+
+	t.Run("param and column branches", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		c := Case[int]().
+			WhenParam(Account.Type.EqParam("archived"), 0).
+			When(Account.Type.IsNull(), Literal(-1)).
+			Else(Account.CreatedTs)
+
+		sql, params := Select(c).From(Account).Where(Account.Uuid.EqParam("u1")).SQL()
+
+		require.Equal(t, "SELECT CASE WHEN account.type = $1 THEN $2 WHEN account.type IS NULL THEN -1 "+
+			"ELSE account.created_ts END FROM account WHERE account.uuid = $3", sql)
+		require.Equal(t, []any{"archived", 0, "u1"}, params)
+	})
+
+	t.Run("without else", func(t *testing.T) {
+		sql, _ := Case[int]().When(Account.Type.EqParam("admin"), Literal(1)).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "CASE WHEN account.type = ? THEN 1 END", sql)
+	})
+
+	t.Run("else param", func(t *testing.T) {
+		sql, params := Case[string]().
+			When(Account.Type.IsNull(), Literal("none")).
+			ElseParam("some").
+			SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "CASE WHEN account.type IS NULL THEN 'none' ELSE ? END", sql)
+		require.Equal(t, []any{"some"}, params.ToSlice())
+	})
+
+	t.Run("in where", func(t *testing.T) {
+		c := Case[int]().When(Account.Type.EqParam("admin"), Literal(10)).Else(Literal(1))
+
+		sql, params := Select(Account.Id).From(Account).Where(c.GtParam(5).And(c.In(Select(Config.CreatedTs).From(Config).AsSubQuery()))).SQL()
+
+		require.Equal(t, "SELECT account.id FROM account WHERE "+
+			"CASE WHEN account.type = ? THEN 10 ELSE 1 END > ? AND "+
+			"CASE WHEN account.type = ? THEN 10 ELSE 1 END IN (SELECT config.created_ts FROM config)", sql)
+		require.Equal(t, []any{"admin", 5, "admin"}, params)
+	})
+
+	t.Run("in group by and order by", func(t *testing.T) {
+		bucket := Case[string]().When(Account.CreatedTs.LtParam(100), Literal("old")).Else(Literal("new"))
+
+		sql, params := Select(bucket, Count()).
+			From(Account).
+			GroupBy(bucket).
+			OrderBy(bucket.Desc()).
+			SQL()
+
+		require.Equal(t, "SELECT CASE WHEN account.created_ts < ? THEN 'old' ELSE 'new' END, COUNT(1) FROM account "+
+			"GROUP BY CASE WHEN account.created_ts < ? THEN 'old' ELSE 'new' END "+
+			"ORDER BY CASE WHEN account.created_ts < ? THEN 'old' ELSE 'new' END DESC", sql)
+		require.Equal(t, []any{100, 100, 100}, params)
+	})
+
+	t.Run("order by alias", func(t *testing.T) {
+		bucket := Case[string]().When(Account.CreatedTs.LtParam(100), Literal("old")).Else(Literal("new"))
+
+		sql, _ := Select(Account.Id, bucket.As("bucket")).From(Account).OrderBy(bucket.Asc()).SQL()
+
+		require.Equal(t, "SELECT account.id, CASE WHEN account.created_ts < ? THEN 'old' ELSE 'new' END AS bucket "+
+			"FROM account ORDER BY bucket ASC", sql)
+	})
+
+	t.Run("derived column of aliased case", func(t *testing.T) {
+		bucket := Case[string]().When(Account.CreatedTs.LtParam(100), Literal("old")).Else(Literal("new"))
+		cte := With("buckets", Select(bucket.As("bucket")).From(Account))
+
+		require.Equal(t, "bucket", DerivedCol(cte, bucket).Name())
+	})
+
+	t.Run("panics without branches", func(t *testing.T) {
+		require.Panics(t, func() { Case[int]().SqlWithParams(&ParamsMap{}, ReferenceContext) })
+	})
+}
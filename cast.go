@@ -0,0 +1,41 @@
+package tomasql
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Cast converts expr to the SQL type matching the Go type To, e.g. Cast[int](Account.Uuid) renders
+// CAST(account.uuid AS INTEGER). The SQL type name is chosen by the current dialect when the query is rendered (see
+// Dialect.CastType).
+func Cast[To any](expr ParametricSql) *FuncCol[To] {
+	return newExprCol[To](&castSql{expr: expr, target: reflect.TypeFor[To]()})
+}
+
+type castSql struct {
+	expr   ParametricSql
+	target reflect.Type
+}
+
+func (c *castSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	exprSql, params := c.expr.SqlWithParams(params, ReferenceContext)
+	return "CAST(" + exprSql + " AS " + castTypeName(params.Dialect(), c.target) + ")", params
+}
+
+// UnsupportedCastError is the panic value used when a Cast targets a Go type that its dialect has no SQL type for.
+type UnsupportedCastError struct {
+	Dialect string
+	Type    reflect.Type
+}
+
+func (e *UnsupportedCastError) Error() string {
+	return fmt.Sprintf("tomasql: no SQL type to cast %s to in the %s dialect", e.Type, e.Dialect)
+}
+
+// castTypeName returns the SQL type name of t in dialect d, or panics with an UnsupportedCastError if there is none.
+func castTypeName(d Dialect, t reflect.Type) string {
+	if sqlType, ok := d.CastType(t); ok {
+		return sqlType
+	}
+	panic(&UnsupportedCastError{Dialect: d.Name(), Type: t})
+}
@@ -0,0 +1,92 @@
+package tomasql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// castTestDialect names integers like MySQL does.
+type castTestDialect struct {
+	StandardDialect
+}
+
+func (d *castTestDialect) CastType(t reflect.Type) (string, bool) {
+	if t.Kind() == reflect.Int {
+		return "SIGNED", true
+	}
+	return d.StandardDialect.CastType(t)
+}
+
+func TestCast(t *testing.T) {
+	type status string
+
+	tests := []struct {
+		name     string
+		expr     ParametricSql
+		expected string
+	}{
+		{"int", Cast[int](Account.Uuid), "CAST(account.uuid AS INTEGER)"},
+		{"int64", Cast[int64](Account.Uuid), "CAST(account.uuid AS BIGINT)"},
+		{"float64", Cast[float64](Account.CreatedTs), "CAST(account.created_ts AS DOUBLE PRECISION)"},
+		{"float32", Cast[float32](Account.CreatedTs), "CAST(account.created_ts AS REAL)"},
+		{"string", Cast[string](Account.Id), "CAST(account.id AS VARCHAR)"},
+		{"named string", Cast[status](Account.Id), "CAST(account.id AS VARCHAR)"},
+		{"bool", Cast[bool](Account.CreatedTs), "CAST(account.created_ts AS BOOLEAN)"},
+		{"time", Cast[time.Time](Account.CreatedTs), "CAST(account.created_ts AS TIMESTAMP)"},
+		{"expression", Cast[float64](Num(Account.CreatedTs).AddParam(1)), "CAST(account.created_ts + ? AS DOUBLE PRECISION)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _ := tt.expr.SqlWithParams(&ParamsMap{}, ReferenceContext)
+			require.Equal(t, tt.expected, sql)
+		})
+	}
+
+	t.Run("type name comes from dialect", func(t *testing.T) {
+		withDialect(t, &castTestDialect{})
+
+		sql, _ := Cast[int](Account.Uuid).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "CAST(account.uuid AS SIGNED)", sql)
+
+		// types unknown to the dialect fall back to the standard names
+		sql, _ = Cast[int64](Account.Uuid).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "CAST(account.uuid AS BIGINT)", sql)
+	})
+
+	t.Run("panics for types without SQL name", func(t *testing.T) {
+		require.PanicsWithError(t, "tomasql: no SQL type to cast struct {} to in the standard dialect", func() {
+			Cast[struct{}](Account.Uuid).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		})
+	})
+
+	t.Run("build returns an error for types without SQL name", func(t *testing.T) {
+		withDialect(t, &StandardDialect{})
+
+		sql, params, err := Select(Cast[[]int](Account.Uuid)).From(Account).Build()
+		var castErr *UnsupportedCastError
+		require.ErrorAs(t, err, &castErr)
+		require.Equal(t, reflect.TypeFor[[]int](), castErr.Type)
+		require.Equal(t, "standard", castErr.Dialect)
+		require.Empty(t, sql)
+		require.Nil(t, params)
+	})
+
+	t.Run("typed comparisons and aggregates", func(t *testing.T) {
+		sql, params := Select(Avg[float64](Cast[float64](Account.CreatedTs)).As("avg_created")).
+			From(Account).
+			Where(Cast[int64](Account.Uuid).EqParam(42)).
+			SQL()
+
+		require.Equal(t, "SELECT AVG(CAST(account.created_ts AS DOUBLE PRECISION)) AS avg_created FROM account "+
+			"WHERE CAST(account.uuid AS BIGINT) = ?", sql)
+		require.Equal(t, []any{int64(42)}, params)
+	})
+
+	t.Run("mixed type arithmetic", func(t *testing.T) {
+		sql, _ := Num(Cast[float64](Account.CreatedTs)).MulParam(1.5).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "CAST(account.created_ts AS DOUBLE PRECISION) * ?", sql)
+	})
+}
@@ -58,10 +58,54 @@ func (c Col[T]) LikeParam(other string) Condition {
 	return NewBinaryParamCondition(c, other, comparerLike)
 }
 
+func (c Col[T]) NotLike(other ParametricSql) Condition {
+	return NewBinaryCondition(c, other, comparerNotLike)
+}
+
+func (c Col[T]) NotLikeParam(pattern string) Condition {
+	return NewBinaryParamCondition(c, pattern, comparerNotLike)
+}
+
+func (c Col[T]) Between(low, high ParametricSql) Condition {
+	return newBetweenCondition(c, low, high)
+}
+
+func (c Col[T]) BetweenParam(low, high T) Condition {
+	return newBetweenCondition(c, Param(low), Param(high))
+}
+
+func (c Col[T]) IsDistinctFrom(other ParametricSql) Condition {
+	return NewBinaryCondition(c, other, comparerDistinctFrom)
+}
+
+func (c Col[T]) IsDistinctFromParam(other T) Condition {
+	return NewBinaryParamCondition(c, other, comparerDistinctFrom)
+}
+
+func (c Col[T]) IsNotDistinctFrom(other ParametricSql) Condition {
+	return NewBinaryCondition(c, other, comparerNotDistinctFrom)
+}
+
+func (c Col[T]) IsNotDistinctFromParam(other T) Condition {
+	return NewBinaryParamCondition(c, other, comparerNotDistinctFrom)
+}
+
 func (c Col[T]) In(sqlable ParametricSql) Condition {
 	return newInCondition(c, sqlable)
 }
 
+func (c Col[T]) NotIn(sqlable ParametricSql) Condition {
+	return newNotInCondition(c, sqlable)
+}
+
+func (c Col[T]) InParams(values ...T) Condition {
+	return newInParamsCondition(c, values, false)
+}
+
+func (c Col[T]) NotInParams(values ...T) Condition {
+	return newInParamsCondition(c, values, true)
+}
+
 func (c Col[T]) EqAny(sqlable ParametricSql) Condition {
 	return newAnyCondition(c, comparerEq, sqlable)
 }
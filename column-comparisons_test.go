@@ -20,7 +20,7 @@ func (s *simpleTable) Alias() *string {
 	return s.alias
 }
 
-func (s *simpleTable) SqlWithParams(params ParamsMap, _ RenderContext) (string, ParamsMap) {
+func (s *simpleTable) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
 	if s.alias != nil {
 		return s.name + " AS " + *s.alias, params
 	}
@@ -49,7 +49,7 @@ func TestCol_BinaryComparisons(t *testing.T) {
 
 			cond := tt.testFn(col1, col2)
 
-			sql := cond.SQL(ParamsMap{})
+			sql := cond.SQL(&ParamsMap{})
 			require.Equal(t, "col1 "+tt.operator+" col2", sql)
 
 			columns := cond.Columns()
@@ -67,7 +67,7 @@ func TestCol_Like(t *testing.T) {
 
 	cond := col1.Like(col2)
 
-	sql := cond.SQL(ParamsMap{})
+	sql := cond.SQL(&ParamsMap{})
 	require.Equal(t, "col1 LIKE col2", sql)
 }
 
@@ -92,10 +92,10 @@ func TestCol_BinaryComparisonsParam(t *testing.T) {
 
 			cond := tt.testFn(col1)
 
-			params := ParamsMap{}
+			params := &ParamsMap{}
 			sql := cond.SQL(params)
 			require.Equal(t, "col1 "+tt.operator+" "+GetDialect().Placeholder(1), sql)
-			require.Equal(t, ParamsMap{42: 1}, params)
+			require.Equal(t, []any{42}, params.ToSlice())
 
 			columns := cond.Columns()
 			require.Len(t, columns, 1)
@@ -110,10 +110,10 @@ func TestCol_LikeParam(t *testing.T) {
 
 	cond := col1.LikeParam("%test%")
 
-	params := ParamsMap{}
+	params := &ParamsMap{}
 	sql := cond.SQL(params)
 	require.Equal(t, "col1 LIKE "+GetDialect().Placeholder(1), sql)
-	require.Equal(t, ParamsMap{"%test%": 1}, params)
+	require.Equal(t, []any{"%test%"}, params.ToSlice())
 }
 
 func TestCol_In(t *testing.T) {
@@ -124,11 +124,87 @@ func TestCol_In(t *testing.T) {
 
 	cond := col1.In(subquery)
 
-	params := ParamsMap{}
+	params := &ParamsMap{}
 	sql := cond.SQL(params)
 	require.Equal(t, "table1.col1 IN (SELECT table1.col2 FROM table1)", sql)
 }
 
+func TestCol_NotIn(t *testing.T) {
+	table := &simpleTable{name: "table1"}
+	col1 := NewCol[int]("col1", table)
+	col2 := NewCol[int]("col2", table)
+	subquery := Select(col2).From(table).AsSubQuery()
+
+	sql := col1.NotIn(subquery).SQL(&ParamsMap{})
+	require.Equal(t, "table1.col1 NOT IN (SELECT table1.col2 FROM table1)", sql)
+}
+
+// TestCol_InParams tests IN and NOT IN with a list of parameters
+func TestCol_InParams(t *testing.T) {
+	withDialect(t, &numberedTestDialect{})
+	col1 := NewCol[int]("col1", nil)
+
+	t.Run("in", func(t *testing.T) {
+		params := &ParamsMap{}
+		sql := col1.InParams(1, 2, 1, 3).SQL(params)
+		require.Equal(t, "col1 IN ($1, $2, $1, $3)", sql)
+		require.Equal(t, []any{1, 2, 3}, params.ToSlice())
+	})
+
+	t.Run("not in", func(t *testing.T) {
+		params := &ParamsMap{}
+		params.Add("x")
+		sql := col1.NotInParams(5, 6).SQL(params)
+		require.Equal(t, "col1 NOT IN ($2, $3)", sql)
+		require.Equal(t, []any{"x", 5, 6}, params.ToSlice())
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		require.Equal(t, "1 = 0", col1.InParams().SQL(&ParamsMap{}))
+		require.Equal(t, "1 = 1", col1.NotInParams().SQL(&ParamsMap{}))
+	})
+}
+
+// TestCol_Between tests BETWEEN with columns and parameters
+func TestCol_Between(t *testing.T) {
+	withDialect(t, &numberedTestDialect{})
+	col1 := NewCol[int]("col1", nil)
+	col2 := NewCol[int]("col2", nil)
+	col3 := NewCol[int]("col3", nil)
+
+	cond := col1.Between(col2, col3)
+	require.Equal(t, "col1 BETWEEN col2 AND col3", cond.SQL(&ParamsMap{}))
+	require.Len(t, cond.Columns(), 3)
+
+	params := &ParamsMap{}
+	require.Equal(t, "col1 BETWEEN $1 AND $2", col1.BetweenParam(10, 20).SQL(params))
+	require.Equal(t, []any{10, 20}, params.ToSlice())
+}
+
+// TestCol_NullSafeAndNegatedComparisons tests NOT LIKE and IS [NOT] DISTINCT FROM
+func TestCol_NullSafeAndNegatedComparisons(t *testing.T) {
+	col1 := NewCol[string]("col1", nil)
+	col2 := NewCol[string]("col2", nil)
+
+	tests := []struct {
+		name     string
+		cond     Condition
+		expected string
+	}{
+		{"NotLike", col1.NotLike(col2), "col1 NOT LIKE col2"},
+		{"NotLikeParam", col1.NotLikeParam("%x"), "col1 NOT LIKE ?"},
+		{"IsDistinctFrom", col1.IsDistinctFrom(col2), "col1 IS DISTINCT FROM col2"},
+		{"IsDistinctFromParam", col1.IsDistinctFromParam("a"), "col1 IS DISTINCT FROM ?"},
+		{"IsNotDistinctFrom", col1.IsNotDistinctFrom(col2), "col1 IS NOT DISTINCT FROM col2"},
+		{"IsNotDistinctFromParam", col1.IsNotDistinctFromParam("a"), "col1 IS NOT DISTINCT FROM ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.cond.SQL(&ParamsMap{}))
+		})
+	}
+}
+
 // TestCol_QuantifiedComparisons tests all quantified comparison operators (ANY/ALL)
 func TestCol_QuantifiedComparisons(t *testing.T) {
 	tests := []struct {
@@ -157,7 +233,7 @@ func TestCol_QuantifiedComparisons(t *testing.T) {
 
 			cond := tt.testFn(col1, subquery)
 
-			params := ParamsMap{}
+			params := &ParamsMap{}
 			sql := cond.SQL(params)
 			require.Equal(t, "table1.col1 "+tt.operator+"(SELECT table1.col2 FROM table1)", sql)
 
@@ -173,7 +249,7 @@ func TestCol_IsNull(t *testing.T) {
 
 	cond := col1.IsNull()
 
-	sql := cond.SQL(ParamsMap{})
+	sql := cond.SQL(&ParamsMap{})
 	require.Equal(t, "col1 IS NULL", sql)
 }
 
@@ -182,7 +258,7 @@ func TestCol_IsNotNull(t *testing.T) {
 
 	cond := col1.IsNotNull()
 
-	sql := cond.SQL(ParamsMap{})
+	sql := cond.SQL(&ParamsMap{})
 	require.Equal(t, "col1 IS NOT NULL", sql)
 }
 
@@ -227,11 +303,11 @@ func TestCol_Comparisons_WithDifferentTypes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cond := tt.testFn()
 
-			params := ParamsMap{}
+			params := &ParamsMap{}
 			sql := cond.SQL(params)
 
 			require.Equal(t, tt.expectedSQL, sql)
-			require.Equal(t, ParamsMap{tt.expectedVal: 1}, params)
+			require.Equal(t, []any{tt.expectedVal}, params.ToSlice())
 		})
 	}
 }
@@ -245,11 +321,11 @@ func TestCol_Comparisons_MultipleParams(t *testing.T) {
 	cond2 := col2.GtParam(20)
 	combined := cond1.And(cond2)
 
-	params := ParamsMap{}
+	params := &ParamsMap{}
 	sql := combined.SQL(params)
 
 	require.Equal(t, "col1 = "+GetDialect().Placeholder(1)+" AND col2 > "+GetDialect().Placeholder(2), sql)
-	require.Equal(t, ParamsMap{10: 1, 20: 2}, params)
+	require.Equal(t, []any{10, 20}, params.ToSlice())
 }
 
 // Test that conditions can be combined with And/Or
@@ -259,13 +335,13 @@ func TestCol_Comparisons_CombinedConditions(t *testing.T) {
 
 	t.Run("AND combination", func(t *testing.T) {
 		cond := col1.Eq(col2).And(col1.Gt(col2))
-		sql := cond.SQL(ParamsMap{})
+		sql := cond.SQL(&ParamsMap{})
 		require.Equal(t, "col1 = col2 AND col1 > col2", sql)
 	})
 
 	t.Run("OR combination", func(t *testing.T) {
 		cond := col1.Eq(col2).Or(col1.Gt(col2))
-		sql := cond.SQL(ParamsMap{})
+		sql := cond.SQL(&ParamsMap{})
 		require.Equal(t, "col1 = col2 OR col1 > col2", sql)
 	})
 }
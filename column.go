@@ -2,6 +2,7 @@ package tomasql
 
 import (
 	"fmt"
+	"reflect"
 )
 
 type RenderContext string
@@ -30,6 +31,13 @@ type Comparable interface {
 	IsNotNull() Condition
 	Like(other ParametricSql) Condition
 	LikeParam(pattern string) Condition
+	NotLike(other ParametricSql) Condition
+	NotLikeParam(pattern string) Condition
+	Between(low, high ParametricSql) Condition
+	// IsDistinctFrom is a null-safe inequality: NULL is distinct from any value, but not from NULL.
+	IsDistinctFrom(other ParametricSql) Condition
+	// IsNotDistinctFrom is a null-safe equality: NULL is not distinct from NULL.
+	IsNotDistinctFrom(other ParametricSql) Condition
 }
 
 type ComparableParam[T any] interface {
@@ -38,11 +46,18 @@ type ComparableParam[T any] interface {
 	GeParam(other T) Condition
 	LtParam(other T) Condition
 	LeParam(other T) Condition
+	BetweenParam(low, high T) Condition
+	IsDistinctFromParam(other T) Condition
+	IsNotDistinctFromParam(other T) Condition
+	// InParams checks membership in a list of values, each passed as a query parameter.
+	InParams(values ...T) Condition
+	NotInParams(values ...T) Condition
 	// InArray(array []T) Condition
 }
 
 type SetComparable interface {
 	In(sqlable ParametricSql) Condition
+	NotIn(sqlable ParametricSql) Condition
 	EqAny(sqlable ParametricSql) Condition
 	EqAll(sqlable ParametricSql) Condition
 	GtAny(sqlable ParametricSql) Condition
@@ -68,12 +83,24 @@ type Column interface {
 	Desc() SortColumn
 
 	getType() colTypeTag
+	// derive returns a column with the same name and type that belongs to table.
+	derive(table Table) Column
+	// goType returns the Go type of the column values.
+	goType() reflect.Type
 
 	ParametricSql
 	Comparable
 	SetComparable
 }
 
+// Expression is a ParametricSql whose value has the Go type T. It allows builders to check at compile time that a
+// value fits the column it is assigned to.
+type Expression[T any] interface {
+	ParametricSql
+	// valueType is never called, it only binds T to the expression.
+	valueType() T
+}
+
 type colTypeTag string
 
 const (
@@ -95,7 +122,7 @@ type Col[T any] struct {
 	ComparableParam[T]
 }
 
-func (c Col[T]) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (c Col[T]) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	if c.Table() == nil {
 		// this is the case for "*"
 		return c.Name(), params
@@ -105,13 +132,13 @@ func (c Col[T]) SqlWithParams(params ParamsMap, ctx RenderContext) (string, Para
 	var tRef string
 	tRef, params = table.SqlWithParams(params, DefinitionContext)
 
-	columnRef := tRef + "." + c.Name()
+	columnRef := tRef + "." + quoteIdent(params.Dialect(), c.Name())
 
 	switch ctx {
 	case DefinitionContext:
 		// Only include alias in SELECT context
 		if c.Alias() != nil {
-			return columnRef + " AS " + *c.Alias(), params
+			return columnRef + " AS " + quoteIdent(params.Dialect(), *c.Alias()), params
 		}
 		return columnRef, params
 	case ReferenceContext:
@@ -120,7 +147,7 @@ func (c Col[T]) SqlWithParams(params ParamsMap, ctx RenderContext) (string, Para
 	case OrderByContext:
 		// Use alias if set, otherwise use table.column reference
 		if c.Alias() != nil {
-			return *c.Alias(), params
+			return quoteIdent(params.Dialect(), *c.Alias()), params
 		}
 		return columnRef, params
 	default:
@@ -208,6 +235,21 @@ func (c Col[T]) getType() colTypeTag {
 	return c.concreteType
 }
 
+func (c Col[T]) derive(table Table) Column {
+	return NewCol[T](c.name, table)
+}
+
+func (c Col[T]) goType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (c Col[T]) valueType() T {
+	var zero T
+	return zero
+}
+
+var _ Expression[int] = Col[int]{}
+
 type Number interface {
 	~int | ~int32 | ~int64 | ~float32 | ~float64
 }
@@ -222,6 +264,13 @@ func NewFixedCol[T string | Number](val T, alias *string) Column {
 	return col
 }
 
+// sortTerm is a sort column that exposes the sorted expression and its direction, so that it can be rendered
+// differently than in the ORDER BY of a query. SortCol implements it.
+type sortTerm interface {
+	sortExpression() ParametricSql
+	sortDirection() SortDirection
+}
+
 type SortCol[T any] struct {
 	// either col or subQuery will be set
 	col       Column
@@ -229,13 +278,32 @@ type SortCol[T any] struct {
 	direction SortDirection
 }
 
-var _ SortColumn = &SortCol[int]{} // Ensure SortCol implements SortColumn
+var (
+	_ SortColumn = &SortCol[int]{} // Ensure SortCol implements SortColumn
+	_ sortTerm   = &SortCol[int]{}
+)
 
 func (s *SortCol[T]) Column() Column {
 	return s.col
 }
 
-func (s *SortCol[T]) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (s *SortCol[T]) sortDirection() SortDirection {
+	return s.direction
+}
+
+// sortExpression returns the sorted expression, without direction. Functions are returned themselves rather than
+// as a reference to their alias.
+func (s *SortCol[T]) sortExpression() ParametricSql {
+	if ref, ok := s.subQuery.(funcColRefWrapper[T]); ok {
+		return ref.funcCol
+	}
+	if s.subQuery != nil {
+		return s.subQuery
+	}
+	return s.col
+}
+
+func (s *SortCol[T]) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	if ctx != OrderByContext {
 		panic(fmt.Sprintf("SortCol.SqlWithParams should only be used with OrderByContext, got %s", ctx))
 	}
@@ -247,12 +315,12 @@ func (s *SortCol[T]) SqlWithParams(params ParamsMap, ctx RenderContext) (string,
 
 	var colRef string
 	if s.col.Alias() != nil {
-		colRef = *s.col.Alias()
+		colRef = quoteIdent(params.Dialect(), *s.col.Alias())
 	} else if s.col.Table() != nil {
 		table := tableRefWrapper{table: s.col.Table()}
 		tableStr, pm := table.SqlWithParams(params, ReferenceContext)
 		params = pm
-		colRef = tableStr + "." + s.col.Name()
+		colRef = tableStr + "." + quoteIdent(params.Dialect(), s.col.Name())
 	} else {
 		colRef = s.col.Name()
 	}
@@ -16,7 +16,6 @@ type anyAllCondition struct {
 	comparer comparerType
 	operator quantifiedOperator
 	sqlable  ParametricSql
-	params   ParamsMap
 }
 
 func (a *anyAllCondition) Columns() []Column {
@@ -38,7 +37,6 @@ func newAnyCondition(col ParametricSql, comparer comparerType, sqlable Parametri
 		comparer: comparer,
 		operator: anyOperator,
 		sqlable:  sqlable,
-		params:   ParamsMap{},
 	}
 }
 
@@ -48,14 +46,13 @@ func newAllCondition(col ParametricSql, comparer comparerType, sqlable Parametri
 		comparer: comparer,
 		operator: allOperator,
 		sqlable:  sqlable,
-		params:   ParamsMap{},
 	}
 }
 
-func (a *anyAllCondition) SQL(params ParamsMap) string {
-	a.params = params.AddAll(a.params)
-	sqlWithParams, _ := a.sqlable.SqlWithParams(a.params, ReferenceContext)
-	colSql, _ := a.col.SqlWithParams(a.params, ReferenceContext)
+func (a *anyAllCondition) SQL(params *ParamsMap) string {
+	// Render the left side first, so that positional placeholders take their parameters in order
+	colSql, _ := a.col.SqlWithParams(params, ReferenceContext)
+	sqlWithParams, _ := a.sqlable.SqlWithParams(params, ReferenceContext)
 
 	return fmt.Sprintf("%s %s %s%s", colSql, a.comparer, a.operator, sqlWithParams)
 }
@@ -31,15 +31,26 @@ func NewConcatCondition(connector ConditionConnector, conditions ...Condition) *
 	return &ConcatCondition{conditions: conditions, connector: connector}
 }
 
-func (c *ConcatCondition) SQL(p ParamsMap) string {
+func (c *ConcatCondition) SQL(p *ParamsMap) string {
 	var innerSQLs []string
 	for _, cond := range c.conditions {
-		innerSQLs = append(innerSQLs, cond.SQL(p))
+		innerSQL := cond.SQL(p)
+		// AND binds tighter than OR: nested concatenations with a different connector are always parenthesized so that
+		// they keep the meaning they were built with, e.g. a.Or(b).And(c) renders (a OR b) AND c.
+		if inner, ok := cond.(*ConcatCondition); ok && c.needsParens(inner) {
+			innerSQL = "(" + innerSQL + ")"
+		}
+		innerSQLs = append(innerSQLs, innerSQL)
 	}
 	connector := fmt.Sprintf(" %s ", c.connector)
 	return strings.Join(innerSQLs, connector)
 }
 
+// needsParens reports whether inner must be parenthesized when rendered as one of the conditions of c.
+func (c *ConcatCondition) needsParens(inner *ConcatCondition) bool {
+	return len(c.conditions) > 1 && len(inner.conditions) > 1 && inner.connector != c.connector
+}
+
 func (c *ConcatCondition) And(condition Condition) Condition {
 	return NewConcatCondition(AndCondConnector, c, condition)
 }
@@ -58,7 +69,7 @@ func Grouped(conditions ...Condition) *GroupedCondition {
 	return &GroupedCondition{ConcatCondition{conditions: conditions}}
 }
 
-func (g *GroupedCondition) SQL(p ParamsMap) string {
+func (g *GroupedCondition) SQL(p *ParamsMap) string {
 	return fmt.Sprintf("(%s)", g.ConcatCondition.SQL(p))
 }
 
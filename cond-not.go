@@ -0,0 +1,31 @@
+package tomasql
+
+import "fmt"
+
+// NotCondition negates another condition.
+type NotCondition struct {
+	inner Condition
+}
+
+func (n *NotCondition) Columns() []Column {
+	return n.inner.Columns()
+}
+
+var _ Condition = &NotCondition{} // Ensure NotCondition implements Condition
+
+// Not negates cond, e.g. Not(a.Or(b)) renders NOT (a OR b).
+func Not(cond Condition) *NotCondition {
+	return &NotCondition{inner: cond}
+}
+
+func (n *NotCondition) SQL(p *ParamsMap) string {
+	return fmt.Sprintf("NOT (%s)", n.inner.SQL(p))
+}
+
+func (n *NotCondition) And(condition Condition) Condition {
+	return NewConcatCondition(AndCondConnector, n, condition)
+}
+
+func (n *NotCondition) Or(condition Condition) Condition {
+	return NewConcatCondition(OrCondConnector, n, condition)
+}
@@ -2,36 +2,92 @@ package tomasql
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 )
 
-type ParamsMap map[any]int
+// ParamsMap holds the state of a query while it is rendered: the dialect it is rendered with and its parameters, in
+// the order of their placeholders. The zero value renders with the default dialect, see SetDialect.
+type ParamsMap struct {
+	dialect   Dialect
+	values    []any
+	positions map[any]int
+	// inQuery is set once the first query of the statement starts rendering, so that the next ones are nested in it
+	inQuery bool
+	// top is the TOP n clause that topStage renders right after SELECT [DISTINCT], for dialects that limit rows with it
+	top      string
+	topStage *builderWithSelect
+}
+
+// NewParamsMap returns the state to render a query with dialect d, or with the default dialect if d is nil.
+func NewParamsMap(d Dialect) *ParamsMap {
+	return &ParamsMap{dialect: d}
+}
+
+// Dialect returns the dialect the query is rendered with.
+func (p *ParamsMap) Dialect() Dialect {
+	if p.dialect == nil {
+		// resolve the default dialect once, so that the whole query uses it even if SetDialect is called meanwhile
+		p.dialect = GetDialect()
+	}
+	return p.dialect
+}
 
 // ToSlice returns a slice of parameters' values respecting their order as placeholders
-func (p ParamsMap) ToSlice() []any {
-	out := make([]any, len(p))
-	for pVal, pOrder := range p {
-		out[pOrder-1] = pVal
-	}
+func (p *ParamsMap) ToSlice() []any {
+	out := make([]any, len(p.values))
+	copy(out, p.values)
 	return out
 }
 
-func (p ParamsMap) AddAll(toAdd ParamsMap) ParamsMap {
-	for val := range toAdd {
-		if _, ok := p[val]; !ok {
-			p[val] = len(p) + 1
-		}
+// Add registers value as a parameter and returns its placeholder position. With numbered placeholders, a comparable
+// value that is already present keeps its position; otherwise every placeholder gets its own parameter.
+func (p *ParamsMap) Add(value any) int {
+	// values that cannot be map keys, e.g. []byte, are never shared
+	if t := reflect.TypeOf(value); !p.Dialect().NumberedPlaceholders() || t != nil && !t.Comparable() {
+		p.values = append(p.values, value)
+		return len(p.values)
+	}
+	if position, ok := p.positions[value]; ok {
+		return position
 	}
+	if p.positions == nil {
+		p.positions = map[any]int{}
+	}
+	p.values = append(p.values, value)
+	p.positions[value] = len(p.values)
+	return len(p.values)
+}
+
+// enterQuery marks the start of a query and reports whether it is nested in another query of the statement, e.g. a
+// subquery, a CTE or the right operand of a set operation.
+func (p *ParamsMap) enterQuery() (nested bool) {
+	nested = p.inQuery
+	p.inQuery = true
+	return nested
+}
 
-	if len(p) == 0 {
-		// If no parameters were added, return a new map to avoid returning nil
-		return ParamsMap{}
+// setTop makes stage render the TOP n clause top.
+func (p *ParamsMap) setTop(stage *builderWithSelect, top string) {
+	p.top, p.topStage = top, stage
+}
+
+// takeTop returns the TOP n clause that stage renders, followed by a space, or an empty string.
+func (p *ParamsMap) takeTop(stage *builderWithSelect) string {
+	if p.topStage != stage {
+		return ""
 	}
+	p.topStage = nil
+	return p.top + " "
+}
 
-	return p
+// Placeholder registers value as a parameter and returns its placeholder in the dialect of the query.
+func (p *ParamsMap) Placeholder(value any) string {
+	return p.Dialect().Placeholder(p.Add(value))
 }
 
 type Condition interface {
-	SQL(ParamsMap) string
+	SQL(*ParamsMap) string
 	And(Condition) Condition
 	Or(Condition) Condition
 	// Columns returns the columns used in this condition, if applicable.
@@ -50,6 +106,10 @@ const (
 	comparerNull    = comparerType("NULL")
 	comparerNotNull = comparerType("NOT NULL")
 	comparerLike    = comparerType("LIKE")
+	comparerNotLike = comparerType("NOT LIKE")
+
+	comparerDistinctFrom    = comparerType("IS DISTINCT FROM")
+	comparerNotDistinctFrom = comparerType("IS NOT DISTINCT FROM")
 )
 
 // IdentityCond represents a default condition that always evaluates to true (1 = 1). Could be useful in cases where
@@ -79,13 +139,25 @@ func NewBinaryCondition(left, right ParametricSql, comparer comparerType) *Binar
 	return &BinaryCondition{left: left, right: right, comparer: comparer}
 }
 
-func (b *BinaryCondition) SQL(p ParamsMap) string {
+func (b *BinaryCondition) SQL(p *ParamsMap) string {
 	var leftSql, rightSql string
 	params := p
 	// Use WhereContext when rendering columns in conditions
 	leftSql, params = b.left.SqlWithParams(params, ReferenceContext)
 	rightSql, _ = b.right.SqlWithParams(params, ReferenceContext)
-	return fmt.Sprintf("%s %s %s", leftSql, b.comparer, rightSql)
+	return comparisonSql(p.Dialect(), leftSql, b.comparer, rightSql)
+}
+
+// comparisonSql renders `left comparer right`, letting dialect d spell the null-safe comparisons.
+func comparisonSql(d Dialect, left string, comparer comparerType, right string) string {
+	switch comparer {
+	case comparerDistinctFrom:
+		return renderOperator(d, OperatorDistinctFrom, left, right)
+	case comparerNotDistinctFrom:
+		return renderOperator(d, OperatorNotDistinctFrom, left, right)
+	default:
+		return fmt.Sprintf("%s %s %s", left, comparer, right)
+	}
 }
 
 func (b *BinaryCondition) And(condition Condition) Condition {
@@ -116,15 +188,10 @@ func NewBinaryParamCondition[T any](col ParametricSql, param T, comparer compare
 	return &BinaryParamCondition[T]{col: col, param: param, comparer: comparer}
 }
 
-func (b *BinaryParamCondition[T]) SQL(params ParamsMap) string {
-	// If the parameter is not already in the map, add it
-	if _, ok := params[b.param]; !ok {
-		params[b.param] = len(params) + 1
-	}
-
+func (b *BinaryParamCondition[T]) SQL(params *ParamsMap) string {
+	// Render the left side first: it can hold parameters of its own (e.g. CASE expressions)
 	colSql, _ := b.col.SqlWithParams(params, ReferenceContext)
-	placeholder := GetDialect().Placeholder(params[b.param])
-	return fmt.Sprintf("%s %s %s", colSql, b.comparer, placeholder)
+	return comparisonSql(params.Dialect(), colSql, b.comparer, params.Placeholder(b.param))
 }
 
 func (b *BinaryParamCondition[T]) And(condition Condition) Condition {
@@ -138,6 +205,7 @@ func (b *BinaryParamCondition[T]) Or(condition Condition) Condition {
 type InCondition struct {
 	col     ParametricSql
 	sqlable ParametricSql
+	negated bool
 }
 
 func (i *InCondition) Columns() []Column {
@@ -157,10 +225,19 @@ func newInCondition(col, sqlable ParametricSql) *InCondition {
 	return &InCondition{col: col, sqlable: sqlable}
 }
 
-func (i *InCondition) SQL(params ParamsMap) string {
-	subquerySql, _ := i.sqlable.SqlWithParams(params, ReferenceContext)
+func newNotInCondition(col, sqlable ParametricSql) *InCondition {
+	return &InCondition{col: col, sqlable: sqlable, negated: true}
+}
+
+func (i *InCondition) SQL(params *ParamsMap) string {
+	// Render the left side first, so that positional placeholders take their parameters in order
 	colSql, _ := i.col.SqlWithParams(params, ReferenceContext)
-	sql := fmt.Sprintf("%s IN %s", colSql, subquerySql)
+	subquerySql, _ := i.sqlable.SqlWithParams(params, ReferenceContext)
+	operator := "IN"
+	if i.negated {
+		operator = "NOT IN"
+	}
+	sql := fmt.Sprintf("%s %s %s", colSql, operator, subquerySql)
 	return sql
 }
 
@@ -172,6 +249,92 @@ func (i *InCondition) Or(condition Condition) Condition {
 	return NewConcatCondition(OrCondConnector, i, condition)
 }
 
+// InParamsCondition checks a column against a list of values, each passed as a query parameter.
+type InParamsCondition[T any] struct {
+	col     ParametricSql
+	values  []T
+	negated bool
+}
+
+func (i *InParamsCondition[T]) Columns() []Column {
+	var cols []Column
+	if col, ok := i.col.(Column); ok {
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+var _ Condition = &InParamsCondition[int]{}
+
+func newInParamsCondition[T any](col ParametricSql, values []T, negated bool) *InParamsCondition[T] {
+	return &InParamsCondition[T]{col: col, values: values, negated: negated}
+}
+
+func (i *InParamsCondition[T]) SQL(params *ParamsMap) string {
+	if len(i.values) == 0 {
+		// IN () is not valid SQL: nothing is in an empty list
+		if i.negated {
+			return "1 = 1"
+		}
+		return "1 = 0"
+	}
+	colSql, _ := i.col.SqlWithParams(params, ReferenceContext)
+	placeholders := make([]string, len(i.values))
+	for ix, value := range i.values {
+		placeholders[ix] = params.Placeholder(value)
+	}
+	operator := "IN"
+	if i.negated {
+		operator = "NOT IN"
+	}
+	return fmt.Sprintf("%s %s (%s)", colSql, operator, strings.Join(placeholders, ", "))
+}
+
+func (i *InParamsCondition[T]) And(condition Condition) Condition {
+	return NewConcatCondition(AndCondConnector, i, condition)
+}
+
+func (i *InParamsCondition[T]) Or(condition Condition) Condition {
+	return NewConcatCondition(OrCondConnector, i, condition)
+}
+
+type BetweenCondition struct {
+	col  ParametricSql
+	low  ParametricSql
+	high ParametricSql
+}
+
+func (b *BetweenCondition) Columns() []Column {
+	var cols []Column
+	for _, operand := range []ParametricSql{b.col, b.low, b.high} {
+		if col, ok := operand.(Column); ok {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+var _ Condition = &BetweenCondition{}
+
+func newBetweenCondition(col, low, high ParametricSql) *BetweenCondition {
+	return &BetweenCondition{col: col, low: low, high: high}
+}
+
+func (b *BetweenCondition) SQL(params *ParamsMap) string {
+	colSql, _ := b.col.SqlWithParams(params, ReferenceContext)
+	lowSql, _ := b.low.SqlWithParams(params, ReferenceContext)
+	highSql, _ := b.high.SqlWithParams(params, ReferenceContext)
+	return fmt.Sprintf("%s BETWEEN %s AND %s", colSql, lowSql, highSql)
+}
+
+func (b *BetweenCondition) And(condition Condition) Condition {
+	return NewConcatCondition(AndCondConnector, b, condition)
+}
+
+func (b *BetweenCondition) Or(condition Condition) Condition {
+	return NewConcatCondition(OrCondConnector, b, condition)
+}
+
 type IsCondition struct {
 	col      ParametricSql
 	comparer comparerType
@@ -191,7 +354,7 @@ func newIsCondition(col ParametricSql, comparer comparerType) *IsCondition {
 	return &IsCondition{col: col, comparer: comparer}
 }
 
-func (i IsCondition) SQL(params ParamsMap) string {
+func (i IsCondition) SQL(params *ParamsMap) string {
 	colSql, _ := i.col.SqlWithParams(params, ReferenceContext)
 	return fmt.Sprintf("%s IS %s", colSql, i.comparer)
 }
@@ -222,7 +385,7 @@ func NewExistsCondition(inner ParametricSql) *ExistsCondition {
 	return &ExistsCondition{inner: inner}
 }
 
-func (e *ExistsCondition) SQL(paramsMap ParamsMap) string {
+func (e *ExistsCondition) SQL(paramsMap *ParamsMap) string {
 	innerSql, _ := e.inner.SqlWithParams(paramsMap, ReferenceContext)
 	return fmt.Sprintf("EXISTS(%s)", innerSql)
 }
@@ -80,9 +80,55 @@ func TestCondition_Columns(t *testing.T) {
 
 	for _, testItem := range tests {
 		got := testItem.impl.Columns()
-		name := testItem.impl.SQL(ParamsMap{})
+		name := testItem.impl.SQL(&ParamsMap{})
 		t.Run(testItem.name+"_"+name, func(tt *testing.T) {
 			require.ElementsMatch(tt, testItem.want, got)
 		})
 	}
 }
+
+func TestNot(t *testing.T) {
+	t.Run("simple condition", func(t *testing.T) {
+		sql, params := Select(Account.Id).From(Account).Where(Not(Account.Type.EqParam("admin"))).SQL()
+		require.Equal(t, "SELECT account.id FROM account WHERE NOT (account.type = ?)", sql)
+		require.Equal(t, []any{"admin"}, params)
+	})
+
+	t.Run("concatenated condition", func(t *testing.T) {
+		cond := Not(Account.Type.IsNull().Or(Account.CreatedTs.BetweenParam(1, 10))).And(Account.Uuid.IsNotNull())
+		require.Equal(t, "NOT (account.type IS NULL OR account.created_ts BETWEEN ? AND ?) AND account.uuid IS NOT NULL",
+			cond.SQL(&ParamsMap{}))
+		require.Len(t, cond.Columns(), 3)
+	})
+
+	t.Run("function column conditions", func(t *testing.T) {
+		cond := Not(Count().InParams(1, 2)).Or(Count().NotLike(Account.Type))
+		require.Equal(t, "NOT (COUNT(1) IN (?, ?)) OR COUNT(1) NOT LIKE account.type", cond.SQL(&ParamsMap{}))
+	})
+}
+
+func TestConcatCondition_Precedence(t *testing.T) {
+	a := Account.Id.EqParam(1)
+	b := Account.Type.IsNull()
+	c := Account.Uuid.IsNotNull()
+	d := Account.CreatedTs.GtParam(10)
+
+	tests := []struct {
+		name     string
+		cond     Condition
+		expected string
+	}{
+		{"or then and", a.Or(b).And(c), "(account.id = ? OR account.type IS NULL) AND account.uuid IS NOT NULL"},
+		{"and then or", a.And(b).Or(c), "(account.id = ? AND account.type IS NULL) OR account.uuid IS NOT NULL"},
+		{"nested right", a.And(b.Or(c)), "account.id = ? AND (account.type IS NULL OR account.uuid IS NOT NULL)"},
+		{"same connector", a.And(b).And(c.And(d)), "account.id = ? AND account.type IS NULL AND account.uuid IS NOT NULL AND account.created_ts > ?"},
+		{"both sides", a.Or(b).And(c.Or(d)), "(account.id = ? OR account.type IS NULL) AND (account.uuid IS NOT NULL OR account.created_ts > ?)"},
+		{"deep nesting", a.Or(b.And(c.Or(d))), "account.id = ? OR (account.type IS NULL AND (account.uuid IS NOT NULL OR account.created_ts > ?))"},
+		{"grouped is not parenthesized twice", a.And(Grouped(b.Or(c))), "account.id = ? AND (account.type IS NULL OR account.uuid IS NOT NULL)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.cond.SQL(&ParamsMap{}))
+		})
+	}
+}
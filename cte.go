@@ -0,0 +1,256 @@
+package tomasql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CTE is a common table expression (WITH name AS (...)). It can be used as a table in From/Join; the WITH clause is
+// rendered at the start of the query that uses it, which is a nested WITH if that query is a subquery.
+type CTE struct {
+	*cteDefinition
+	alias *string
+}
+
+type cteDefinition struct {
+	name  string
+	query SubQueryable
+	// recursive term, only set for recursive CTEs
+	recursive *recursiveTerm
+}
+
+type recursiveTerm struct {
+	query SubQueryable
+	all   bool
+}
+
+// RecursiveCTE is a common table expression rendered in a WITH RECURSIVE clause. Its query is the anchor query, which
+// is combined with a recursive term through Union or UnionAll. Columns of the CTE can be referenced in the recursive
+// term via DerivedCol.
+type RecursiveCTE struct {
+	*CTE
+}
+
+var _ Table = &CTE{}
+
+func newCTE(name string, query SubQueryable) *CTE {
+	return &CTE{cteDefinition: &cteDefinition{name: name, query: query}}
+}
+
+func (c *CTE) TableName() string {
+	return c.name
+}
+
+func (c *CTE) Alias() *string {
+	return c.alias
+}
+
+// As returns a reference to the same CTE under a different alias, e.g. to join it with itself.
+func (c *CTE) As(alias string) *CTE {
+	return &CTE{cteDefinition: c.cteDefinition, alias: &alias}
+}
+
+func (c *CTE) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	return newSqlableTable(c).SqlWithParams(params, ctx)
+}
+
+// selectItems returns the select list of the CTE query.
+func (c *CTE) selectItems() []ParametricSql {
+	return selectItemsOf(c.query)
+}
+
+// Union combines the anchor query with the recursive term using UNION, which discards duplicate rows.
+func (c *RecursiveCTE) Union(term SubQueryable) *CTE {
+	return c.setRecursiveTerm(term, false)
+}
+
+// UnionAll combines the anchor query with the recursive term using UNION ALL.
+func (c *RecursiveCTE) UnionAll(term SubQueryable) *CTE {
+	return c.setRecursiveTerm(term, true)
+}
+
+func (c *RecursiveCTE) setRecursiveTerm(term SubQueryable, all bool) *CTE {
+	if c.recursive.query != nil {
+		panic(fmt.Sprintf("recursive term of %s already set", c.name))
+	}
+	// the term references the CTE itself, which must not be defined again inside of it
+	if with := withClauseOf(term); with != nil {
+		with.unregister(c.cteDefinition)
+	}
+	c.recursive.query = term
+	c.recursive.all = all
+	return c.CTE
+}
+
+// definitionSql renders `name AS (query)`.
+func (d *cteDefinition) definitionSql(params *ParamsMap) (string, *ParamsMap) {
+	querySql, params := d.query.SqlWithParams(params, DefinitionContext)
+	if d.recursive != nil && d.recursive.query != nil {
+		union := " UNION "
+		if d.recursive.all {
+			union = " UNION ALL "
+		}
+		var termSql string
+		termSql, params = d.recursive.query.SqlWithParams(params, DefinitionContext)
+		querySql += union + termSql
+	}
+	return quoteIdent(params.Dialect(), d.name) + " AS (" + querySql + ")", params
+}
+
+// withClause holds the CTEs used by a statement.
+type withClause struct {
+	ctes []*cteDefinition
+}
+
+// register adds the definition of t to the clause if t is a CTE that was not registered yet.
+func (w *withClause) register(t Table) {
+	def := cteDefinitionOf(t)
+	if def == nil {
+		return
+	}
+	for _, registered := range w.ctes {
+		if registered == def {
+			return
+		}
+	}
+	w.ctes = append(w.ctes, def)
+}
+
+// unregister removes def from the clause, if present.
+func (w *withClause) unregister(def *cteDefinition) {
+	w.ctes = slices.DeleteFunc(w.ctes, func(registered *cteDefinition) bool {
+		return registered == def
+	})
+}
+
+// SqlWithParams renders the WITH clause, followed by a space, or an empty string if there are no CTEs. It is rendered
+// at the start of every query, so it also tracks whether the query is nested in another one.
+func (w *withClause) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	nested := params.enterQuery()
+	if len(w.ctes) == 0 {
+		return "", params
+	}
+	if nested {
+		requireFeature(params.Dialect(), FeatureNestedWith)
+	}
+	keyword := "WITH "
+	defsSql := make([]string, len(w.ctes))
+	for i, def := range w.ctes {
+		if def.recursive != nil {
+			requireFeature(params.Dialect(), FeatureRecursiveCTE)
+			keyword = params.Dialect().WithRecursive() + " "
+		}
+		defsSql[i], params = def.definitionSql(params)
+	}
+	return keyword + strings.Join(defsSql, ", ") + " ", params
+}
+
+// chainedStage is implemented by builder stages that extend a previous stage.
+type chainedStage interface {
+	previousStage() ParametricSql
+}
+
+// rootStage returns the first stage of a query, usually its SELECT stage.
+func rootStage(stage ParametricSql) ParametricSql {
+	for {
+		switch s := stage.(type) {
+		case chainedStage:
+			stage = s.previousStage()
+		case *withOptionalAlias:
+			stage = s.SQLable
+		default:
+			return stage
+		}
+	}
+}
+
+// cteDefinitionOf returns the definition of t if t is a CTE, nil otherwise.
+func cteDefinitionOf(t Table) *cteDefinition {
+	switch cte := t.(type) {
+	case *CTE:
+		return cte.cteDefinition
+	case *RecursiveCTE:
+		return cte.cteDefinition
+	default:
+		return nil
+	}
+}
+
+// withClauseOf returns the WITH clause of the query that stage belongs to, or nil if it has none.
+func withClauseOf(stage ParametricSql) *withClause {
+	switch root := rootStage(stage).(type) {
+	case *builderWithSelect:
+		return &root.with
+	case *builderWithSelectAll:
+		return &root.with
+	default:
+		return nil
+	}
+}
+
+// registerCTE registers t in the WITH clause of the query that stage belongs to, if t is a CTE.
+func registerCTE(stage ParametricSql, t Table) {
+	if cteDefinitionOf(t) == nil {
+		return
+	}
+	if with := withClauseOf(stage); with != nil {
+		with.register(t)
+	}
+}
+
+// selectItemsOf returns the select list of a query, or nil if it is not known (e.g. SELECT *).
+func selectItemsOf(query ParametricSql) []ParametricSql {
+	if root, ok := rootStage(query).(*builderWithSelect); ok {
+		return root.selectColumns
+	}
+	return nil
+}
+
+// DerivedCol returns the column of t (a CTE, a named subquery or a VALUES list) that exposes source, which must be an
+// item of the select list of t, or one of the columns given to ValuesList.As. The column keeps the type of source and is named after its alias, if any.
+func DerivedCol[T any](t Table, source Expression[T]) *Col[T] {
+	var items []ParametricSql
+	switch table := t.(type) {
+	case *CTE:
+		items = table.selectItems()
+	case *RecursiveCTE:
+		items = table.selectItems()
+	case *withOptionalAlias:
+		items = selectItemsOf(table.SQLable)
+	case *valuesTable:
+		items = table.sourceColumns
+	}
+
+	for _, item := range items {
+		if name, ok := derivedColName(item, source); ok {
+			return NewCol[T](name, t)
+		}
+	}
+	panic(fmt.Sprintf("DerivedCol: expression is not in the select list of %s", t.TableName()))
+}
+
+// derivedColName returns the name under which a select item is exposed if it is source.
+func derivedColName(item ParametricSql, source ParametricSql) (string, bool) {
+	switch itemCol := item.(type) {
+	case Column:
+		sourceCol, ok := source.(Column)
+		if !ok || !sameColumn(itemCol, sourceCol) {
+			return "", false
+		}
+		if itemCol.Alias() != nil {
+			return *itemCol.Alias(), true
+		}
+		return itemCol.Name(), true
+	case FuncColumn:
+		if item != source {
+			return "", false
+		}
+		if itemCol.Alias() == nil {
+			panic("DerivedCol: function columns must have an alias to be referenced")
+		}
+		return *itemCol.Alias(), true
+	default:
+		return "", false
+	}
+}
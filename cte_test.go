@@ -0,0 +1,240 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	t.Run("from cte", func(t *testing.T) {
+		vip := With("vip", Select(Account.Id, Account.Uuid).From(Account).Where(Account.Type.EqParam("vip")))
+		vipUuid := DerivedCol(vip, Account.Uuid)
+
+		sql, params := Select(vipUuid).From(vip).SQL()
+
+		require.Equal(t, "WITH vip AS (SELECT account.id, account.uuid FROM account WHERE account.type = ?) "+
+			"SELECT vip.uuid FROM vip", sql)
+		require.Equal(t, []any{"vip"}, params)
+	})
+
+	t.Run("join cte", func(t *testing.T) {
+		carts := With("carts", Select(ShoppingCart.OwnerId, Count().As("cart_count")).
+			From(ShoppingCart).
+			GroupBy(ShoppingCart.OwnerId))
+		cartOwner := DerivedCol(carts, ShoppingCart.OwnerId)
+		cartCount := NewCol[int]("cart_count", carts)
+
+		sql, _ := Select(Account.Id, cartCount).
+			From(Account).
+			Join(carts).On(Account.Id.Eq(cartOwner)).
+			OrderBy(cartCount.Desc()).
+			SQL()
+
+		require.Equal(t, "WITH carts AS (SELECT shopping_cart.owner_id, COUNT(1) AS cart_count "+
+			"FROM shopping_cart GROUP BY shopping_cart.owner_id) "+
+			"SELECT account.id, carts.cart_count FROM account JOIN carts ON account.id = carts.owner_id "+
+			"ORDER BY carts.cart_count DESC", sql)
+	})
+
+	t.Run("multiple ctes and select all", func(t *testing.T) {
+		accounts := With("accounts", Select(Account.Id).From(Account))
+		configs := With("configs", Select(Config.AccountId).From(Config))
+
+		sql, _ := SelectAll().
+			From(accounts).
+			LeftJoin(configs).On(DerivedCol(accounts, Account.Id).Eq(DerivedCol(configs, Config.AccountId))).
+			SQL()
+
+		require.Equal(t, "WITH accounts AS (SELECT account.id FROM account), configs AS (SELECT config.account_id FROM config) "+
+			"SELECT * FROM accounts LEFT JOIN configs ON accounts.id = configs.account_id", sql)
+	})
+
+	t.Run("self join on aliased cte", func(t *testing.T) {
+		accounts := With("accounts", Select(Account.Id, Account.CreatedTs).From(Account))
+		a1 := accounts.As("a1")
+		a2 := accounts.As("a2")
+
+		sql, _ := Select(DerivedCol(a1, Account.Id), DerivedCol(a2, Account.Id)).
+			From(a1).
+			Join(a2).On(DerivedCol(a1, Account.CreatedTs).Lt(DerivedCol(a2, Account.CreatedTs))).
+			SQL()
+
+		require.Equal(t, "WITH accounts AS (SELECT account.id, account.created_ts FROM account) "+
+			"SELECT a1.id, a2.id FROM accounts AS a1 JOIN accounts AS a2 ON a1.created_ts < a2.created_ts", sql)
+	})
+
+	t.Run("params are numbered in order", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		recent := With("recent", Select(Account.Id).From(Account).Where(Account.CreatedTs.GtParam(100)))
+
+		sql, params := Select(DerivedCol(recent, Account.Id)).
+			From(recent).
+			Join(Config).On(Config.AccountId.Eq(DerivedCol(recent, Account.Id))).
+			Where(Config.Uuid.EqParam("u1").And(Config.CreatedTs.GtParam(100))).
+			SQL()
+
+		require.Equal(t, "WITH recent AS (SELECT account.id FROM account WHERE account.created_ts > $1) "+
+			"SELECT recent.id FROM recent JOIN config ON config.account_id = recent.id "+
+			"WHERE config.uuid = $2 AND config.created_ts > $1", sql)
+		require.Equal(t, []any{100, "u1"}, params)
+	})
+
+	t.Run("as subquery", func(t *testing.T) {
+		recent := With("recent", Select(Account.Id).From(Account).Where(Account.CreatedTs.GtParam(100)))
+		inner := Select(DerivedCol(recent, Account.Id)).From(recent).AsSubQuery()
+
+		sql, params := Select(Config.Id).From(Config).Where(Config.AccountId.In(inner)).SQL()
+
+		require.Equal(t, "SELECT config.id FROM config WHERE config.account_id IN "+
+			"(WITH recent AS (SELECT account.id FROM account WHERE account.created_ts > ?) SELECT recent.id FROM recent)", sql)
+		require.Equal(t, []any{100}, params)
+	})
+
+	t.Run("nested with needs the dialect support", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{})
+
+		recent := With("recent", Select(Account.Id).From(Account))
+		inner := Select(DerivedCol(recent, Account.Id)).From(recent)
+
+		_, _, err := inner.Build()
+		require.NoError(t, err)
+
+		_, _, err = Select(Config.Id).From(Config).Where(Config.AccountId.In(inner.AsSubQuery())).Build()
+		require.EqualError(t, err, "tomasql: WITH in a subquery is not supported by the numbered dialect")
+
+		_, _, err = Select(Config.AccountId).From(Config).Union(inner).Build()
+		require.EqualError(t, err, "tomasql: WITH in a subquery is not supported by the numbered dialect")
+	})
+
+	t.Run("update from cte", func(t *testing.T) {
+		vip := With("vip", Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip")))
+
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.SetParam(1)).
+			From(vip).
+			Where(Config.AccountId.Eq(DerivedCol(vip, Account.Id))).
+			SQL()
+
+		require.Equal(t, "WITH vip AS (SELECT account.id FROM account WHERE account.type = ?) "+
+			"UPDATE config SET archived_ts = ? FROM vip WHERE config.account_id = vip.id", sql)
+		require.Equal(t, []any{"vip", 1}, params)
+	})
+
+	t.Run("delete using cte", func(t *testing.T) {
+		vip := With("vip", Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip")))
+
+		sql, _ := DeleteFrom(Config).
+			Using(vip).
+			Where(Config.AccountId.Eq(DerivedCol(vip, Account.Id))).
+			SQL()
+
+		require.Equal(t, "WITH vip AS (SELECT account.id FROM account WHERE account.type = ?) "+
+			"DELETE FROM config USING vip WHERE config.account_id = vip.id", sql)
+	})
+}
+
+func TestDerivedCol(t *testing.T) {
+	t.Run("uses column alias", func(t *testing.T) {
+		cte := With("c", Select(Account.Id.As("account_id")).From(Account))
+		var col *Col[int64] = DerivedCol(cte, Account.Id)
+		require.Equal(t, "account_id", col.Name())
+	})
+
+	t.Run("function column with alias", func(t *testing.T) {
+		cnt := Count()
+		cte := With("c", Select(Account.Type, cnt.As("total")).From(Account).GroupBy(Account.Type))
+		var col *Col[int] = DerivedCol(cte, cnt)
+		require.Equal(t, "total", col.Name())
+	})
+
+	t.Run("named subquery", func(t *testing.T) {
+		sub := Select(Account.Uuid).From(Account).AsNamedSubQuery("sub")
+		sql, _ := DerivedCol(sub, Account.Uuid).SqlWithParams(&ParamsMap{}, ReferenceContext)
+		require.Equal(t, "sub.uuid", sql)
+	})
+
+	t.Run("panics when not in select list", func(t *testing.T) {
+		cte := With("c", Select(Account.Id).From(Account))
+		require.Panics(t, func() { DerivedCol(cte, Account.Uuid) })
+		require.Panics(t, func() { DerivedCol(cte, Config.Id) })
+	})
+
+	t.Run("panics for function column without alias", func(t *testing.T) {
+		cnt := Count()
+		cte := With("c", Select(cnt).From(Account))
+		require.Panics(t, func() { DerivedCol(cte, cnt) })
+	})
+}
+
+func TestWithRecursive(t *testing.T) {
+	t.Run("union all", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureRecursiveCTE}})
+
+		tree := WithRecursive("tree", Select(Category.Id, Category.ParentId).
+			From(Category).
+			Where(Category.Name.EqParam("root")))
+		treeId := DerivedCol(tree, Category.Id)
+		tree.UnionAll(Select(Category.Id, Category.ParentId).
+			From(Category).
+			Join(tree).On(Category.ParentId.Eq(treeId)).
+			Where(Category.Name.NeqParam("hidden")))
+
+		sql, params := Select(treeId).From(tree).Where(DerivedCol(tree, Category.ParentId).IsNotNull()).SQL()
+
+		require.Equal(t, "WITH RECURSIVE tree AS ("+
+			"SELECT category.id, category.parent_id FROM category WHERE category.name = $1 "+
+			"UNION ALL "+
+			"SELECT category.id, category.parent_id FROM category JOIN tree ON category.parent_id = tree.id WHERE category.name <> $2) "+
+			"SELECT tree.id FROM tree WHERE tree.parent_id IS NOT NULL", sql)
+		require.Equal(t, []any{"root", "hidden"}, params)
+	})
+
+	t.Run("union and aliased reference", func(t *testing.T) {
+		tree := WithRecursive("tree", Select(Category.Id).From(Category).Where(Category.ParentId.IsNull()))
+		tree.Union(Select(Category.Id).From(Category).Join(tree).On(Category.ParentId.Eq(DerivedCol(tree, Category.Id))))
+		t1 := tree.As("t1")
+
+		sql, _ := Select(Category.Name).From(Category).Join(t1).On(Category.Id.Eq(DerivedCol(t1, Category.Id))).SQL()
+
+		require.Equal(t, "WITH RECURSIVE tree AS ("+
+			"SELECT category.id FROM category WHERE category.parent_id IS NULL "+
+			"UNION "+
+			"SELECT category.id FROM category JOIN tree ON category.parent_id = tree.id) "+
+			"SELECT category.name FROM category JOIN tree AS t1 ON category.id = t1.id", sql)
+	})
+
+	t.Run("panics when recursive term is set twice", func(t *testing.T) {
+		tree := WithRecursive("tree", Select(Category.Id).From(Category))
+		tree.UnionAll(Select(Category.Id).From(Category))
+		require.Panics(t, func() { tree.UnionAll(Select(Category.Id).From(Category)) })
+	})
+}
+
+// categoryTableDef is a self-referencing table used to test recursive queries.
+type categoryTableDef struct {
+	*SqlableTable
+	Id       *Col[int64]
+	Name     *Col[string]
+	ParentId *Col[int64]
+}
+
+func newCategoryTable() *categoryTableDef {
+	tDef := &categoryTableDef{}
+	tDef.Id = NewCol[int64]("id", tDef)
+	tDef.Name = NewCol[string]("name", tDef)
+	tDef.ParentId = NewCol[int64]("parent_id", tDef)
+	tDef.SqlableTable = NewSqlableTable(tDef)
+	return tDef
+}
+
+var Category = newCategoryTable()
+
+func (a *categoryTableDef) TableName() string {
+	return "category"
+}
+
+func (a *categoryTableDef) Alias() *string {
+	return nil
+}
@@ -1,5 +1,16 @@
 package tomasql
 
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dialect renders the parts of a query that differ between databases. Implementations can embed StandardDialect and
+// only override the methods in which the database departs from standard SQL.
 type Dialect interface {
 
 	// Name returns the name of the dialect (e.g., "standard", "postgres")
@@ -7,32 +18,348 @@ type Dialect interface {
 
 	// Placeholder returns the parameter placeholder for position n (e.g., $1, ?, :1)
 	Placeholder(position int) string
+
+	// NumberedPlaceholders reports whether placeholders refer to parameters by position, e.g. $1, so that a value used
+	// several times is passed once. Otherwise each placeholder, e.g. ?, takes the next parameter.
+	NumberedPlaceholders() bool
+
+	// QuoteIdentifier quotes a table, column, alias or window name, e.g. with backticks.
+	QuoteIdentifier(name string) string
+
+	// LimitOffset renders the LIMIT and OFFSET of a query, e.g. LIMIT offset, count. Either of limit and offset can be
+	// nil, but not both.
+	LimitOffset(limit, offset *int) string
+
+	// Top renders the TOP n clause of dialects that limit the rows of a query right after SELECT [DISTINCT]. It is
+	// used for queries with a limit and no offset; when ok is false, and for the other queries, LimitOffset is used.
+	Top(limit int) (sql string, ok bool)
+
+	// WithRecursive returns the keyword that opens a WITH clause defining recursive CTEs, e.g. WITH RECURSIVE, or just
+	// WITH for dialects in which any CTE can be recursive.
+	WithRecursive() string
+
+	// Supports reports whether the dialect supports an optional feature. Rendering a query that uses an unsupported
+	// feature panics with an *UnsupportedFeatureError.
+	Supports(feature Feature) bool
+
+	// FunctionName returns the name of a SQL function in the dialect, e.g. LEN for LENGTH.
+	FunctionName(name string) string
+
+	// BoolLiteral renders a boolean literal, e.g. as TRUE or 1.
+	BoolLiteral(value bool) string
+
+	// StringLiteral renders a string literal, escaping the characters that would otherwise end it, e.g. 'it''s'.
+	StringLiteral(value string) string
+
+	// ValuesRow renders a row of a VALUES list used as a table, e.g. (1, 'a'). The values are already rendered.
+	ValuesRow(values []string) string
+
+	// RenderOperator renders op applied to the operands, which are already rendered, e.g. CONCAT(a, b) for a || b.
+	RenderOperator(op Operator, operands []string) string
+
+	// CastType returns the SQL type that Cast converts to for the Go type t. When ok is false, rendering the query
+	// panics with an *UnsupportedCastError.
+	CastType(t reflect.Type) (sqlType string, ok bool)
+}
+
+// Feature identifies an optional SQL construct that not every dialect supports.
+type Feature string
+
+const (
+	// FeatureReturning is the RETURNING clause of INSERT, UPDATE and DELETE statements.
+	FeatureReturning = Feature("RETURNING")
+	// FeatureOnConflict is the ON CONFLICT clause of INSERT statements (Postgres, SQLite).
+	FeatureOnConflict = Feature("ON CONFLICT")
+	// FeatureOnDuplicateKey is the ON DUPLICATE KEY UPDATE clause of INSERT statements (MySQL).
+	FeatureOnDuplicateKey = Feature("ON DUPLICATE KEY UPDATE")
+	// FeatureRightJoin is the RIGHT JOIN of SELECT statements.
+	FeatureRightJoin = Feature("RIGHT JOIN")
+	// FeatureFullJoin is the FULL JOIN of SELECT statements.
+	FeatureFullJoin = Feature("FULL JOIN")
+	// FeatureLateral is the LATERAL join of subqueries.
+	FeatureLateral = Feature("LATERAL")
+	// FeatureJoinUsing is the USING (...) column list of joins, alternative to ON.
+	FeatureJoinUsing = Feature("JOIN USING")
+	// FeatureUpdateFrom is the FROM clause, and its joins, of UPDATE statements.
+	FeatureUpdateFrom = Feature("UPDATE FROM")
+	// FeatureDeleteUsing is the USING clause of DELETE statements (Postgres).
+	FeatureDeleteUsing = Feature("DELETE USING")
+	// FeatureGroupingSets is the ROLLUP, CUBE and GROUPING SETS grouping of SELECT statements.
+	FeatureGroupingSets = Feature("GROUPING SETS")
+	// FeatureUnorderedLimit is the LIMIT or OFFSET of a query without ORDER BY, e.g. of a set operation.
+	FeatureUnorderedLimit = Feature("LIMIT without ORDER BY")
+	// FeatureDerivedColumnList is the column list of a derived table alias, e.g. (VALUES ...) AS v (id, name).
+	FeatureDerivedColumnList = Feature("derived column list")
+	// FeatureParenthesizedSetOperand is a parenthesized query combined by a set operation, e.g.
+	// (SELECT ... LIMIT 1) UNION ALL SELECT ...
+	FeatureParenthesizedSetOperand = Feature("parenthesized set operation operand")
+	// FeatureNestedWith is a WITH clause that does not start the statement, e.g. the WITH of a subquery that uses a CTE.
+	FeatureNestedWith = Feature("WITH in a subquery")
+	// FeatureRecursiveCTE is the recursive common table expressions, introduced by Dialect.WithRecursive.
+	FeatureRecursiveCTE = Feature("WITH RECURSIVE")
+	// FeatureStringAgg is the STRING_AGG aggregate function.
+	FeatureStringAgg = Feature("STRING_AGG")
+	// FeatureArrayAgg is the ARRAY_AGG aggregate function.
+	FeatureArrayAgg = Feature("ARRAY_AGG")
+	// FeatureAggregateOrderBy is the ORDER BY of the values of an aggregate, e.g. STRING_AGG(a, ', ' ORDER BY a).
+	FeatureAggregateOrderBy = Feature("ORDER BY in aggregate functions")
+	// FeatureWithinGroup is the WITHIN GROUP (ORDER BY ...) clause of ordered-set aggregates, e.g. PERCENTILE_CONT.
+	FeatureWithinGroup = Feature("WITHIN GROUP")
+	// FeatureILike is the case-insensitive ILIKE comparison (Postgres).
+	FeatureILike = Feature("ILIKE")
+	// FeatureDistinctOn is the DISTINCT ON clause of SELECT statements (Postgres).
+	FeatureDistinctOn = Feature("DISTINCT ON")
+	// FeatureFilter is the FILTER (WHERE ...) clause of aggregate functions. Without it, filtered aggregates are
+	// rewritten with CASE.
+	FeatureFilter = Feature("FILTER")
+	// FeatureForUpdate is the FOR UPDATE row locking clause of SELECT statements.
+	FeatureForUpdate = Feature("FOR UPDATE")
+	// FeatureForNoKeyUpdate is the FOR NO KEY UPDATE row locking clause of SELECT statements (Postgres).
+	FeatureForNoKeyUpdate = Feature("FOR NO KEY UPDATE")
+	// FeatureForShare is the FOR SHARE row locking clause of SELECT statements.
+	FeatureForShare = Feature("FOR SHARE")
+	// FeatureSkipLocked is the SKIP LOCKED option of row locking clauses.
+	FeatureSkipLocked = Feature("SKIP LOCKED")
+	// FeatureNoWait is the NOWAIT option of row locking clauses.
+	FeatureNoWait = Feature("NOWAIT")
+)
+
+// quoteIdent quotes name as an identifier of dialect d. The * of a star column is never quoted.
+func quoteIdent(d Dialect, name string) string {
+	if name == "" || name == "*" {
+		return name
+	}
+	return d.QuoteIdentifier(name)
+}
+
+// Operator identifies an operator that some dialects spell differently than standard SQL.
+type Operator string
+
+const (
+	// OperatorConcat is the string concatenation a || b.
+	OperatorConcat = Operator("||")
+	// OperatorDistinctFrom is the null-safe inequality a IS DISTINCT FROM b.
+	OperatorDistinctFrom = Operator("IS DISTINCT FROM")
+	// OperatorNotDistinctFrom is the null-safe equality a IS NOT DISTINCT FROM b.
+	OperatorNotDistinctFrom = Operator("IS NOT DISTINCT FROM")
+)
+
+// renderOperator renders op applied to operands in dialect d.
+func renderOperator(d Dialect, op Operator, operands ...string) string {
+	return d.RenderOperator(op, operands)
+}
+
+// UnsupportedFeatureError is the panic value used when a query uses a feature that its dialect does not support,
+// instead of rendering SQL that the database would reject.
+type UnsupportedFeatureError struct {
+	Dialect string
+	Feature Feature
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("tomasql: %s is not supported by the %s dialect", e.Feature, e.Dialect)
+}
+
+// requireFeature panics with an UnsupportedFeatureError if dialect d does not support feature.
+func requireFeature(d Dialect, feature Feature) {
+	if !d.Supports(feature) {
+		panic(&UnsupportedFeatureError{Dialect: d.Name(), Feature: feature})
+	}
 }
 
 // DefaultDialect is used when no dialect is specified
-var DefaultDialect Dialect = &standardDialect{}
+var DefaultDialect Dialect = &StandardDialect{}
 
-var dialect Dialect = DefaultDialect
+var (
+	dialectMu sync.RWMutex
+	dialect   Dialect = DefaultDialect
+)
 
-// SetDialect allows changing the dialect
+// SetDialect sets the default dialect, used by SQL. Queries can be rendered with another dialect through SQLFor or
+// RenderWith.
 func SetDialect(d Dialect) {
+	dialectMu.Lock()
+	defer dialectMu.Unlock()
 	dialect = d
 }
 
-// GetDialect returns the current dialect
+// GetDialect returns the default dialect.
 func GetDialect() Dialect {
+	dialectMu.RLock()
+	defer dialectMu.RUnlock()
 	return dialect
 }
 
-type standardDialect struct {
+// renderer implements the SQL, SQLFor and RenderWith methods of SQLable for the query it is embedded in.
+type renderer struct {
+	query SQLable
 }
 
-var _ Dialect = (*standardDialect)(nil)
+func (r renderer) SQL() (sql string, params []any) {
+	return r.SQLFor(nil)
+}
+
+func (r renderer) SQLFor(d Dialect) (sql string, params []any) {
+	sql, paramsMap := r.query.SqlWithParams(NewParamsMap(d), OutputContext)
+	return sql, paramsMap.ToSlice()
+}
+
+func (r renderer) RenderWith(d Dialect) SQLable {
+	return &dialectQuery{SQLable: r.query, dialect: d}
+}
+
+func (r renderer) Build() (sql string, params []any, err error) {
+	return build(r.query)
+}
+
+// build renders query with SQL and returns the error values SQL panics with. Other panics, which report a misuse of
+// the builders (e.g. a CASE without branches) or a runtime error, are not recovered.
+func build(query SQLable) (sql string, params []any, err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		recoveredErr, ok := recovered.(error)
+		if _, isRuntime := recovered.(runtime.Error); !ok || isRuntime {
+			panic(recovered)
+		}
+		sql, params, err = "", nil, recoveredErr
+	}()
+	sql, params = query.SQL()
+	return sql, params, nil
+}
 
-func (d *standardDialect) Name() string {
+// dialectQuery is a query bound to a dialect by RenderWith.
+type dialectQuery struct {
+	SQLable
+	dialect Dialect
+}
+
+// SQL renders the query with the dialect it is bound to. As a subquery, the query uses the dialect of the outer
+// query instead.
+func (q *dialectQuery) SQL() (sql string, params []any) {
+	return q.SQLFor(q.dialect)
+}
+
+func (q *dialectQuery) RenderWith(d Dialect) SQLable {
+	return &dialectQuery{SQLable: q.SQLable, dialect: d}
+}
+
+func (q *dialectQuery) Build() (sql string, params []any, err error) {
+	return build(q)
+}
+
+// StandardDialect renders standard SQL. Other dialects can embed it and only override the methods that differ.
+type StandardDialect struct {
+}
+
+var _ Dialect = (*StandardDialect)(nil)
+
+func (d *StandardDialect) Name() string {
 	return "standard"
 }
 
-func (d *standardDialect) Placeholder(_ int) string {
+func (d *StandardDialect) Placeholder(_ int) string {
 	return "?"
 }
+
+// NumberedPlaceholders implements Dialect: ? placeholders are positional.
+func (d *StandardDialect) NumberedPlaceholders() bool {
+	return false
+}
+
+// QuoteIdentifier implements Dialect. The standard dialect renders identifiers as they are.
+func (d *StandardDialect) QuoteIdentifier(name string) string {
+	return name
+}
+
+// LimitOffset implements Dialect with LIMIT n OFFSET m.
+func (d *StandardDialect) LimitOffset(limit, offset *int) string {
+	var parts []string
+	if limit != nil {
+		parts = append(parts, fmt.Sprintf("LIMIT %d", *limit))
+	}
+	if offset != nil {
+		parts = append(parts, fmt.Sprintf("OFFSET %d", *offset))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Top implements Dialect. The standard dialect always uses LimitOffset.
+func (d *StandardDialect) Top(_ int) (string, bool) {
+	return "", false
+}
+
+// WithRecursive implements Dialect with WITH RECURSIVE.
+func (d *StandardDialect) WithRecursive() string {
+	return "WITH RECURSIVE"
+}
+
+// Supports implements Dialect. The standard dialect only supports the joins, grouping sets, limits, recursive CTEs
+// and aggregates of the SQL standard, as well as STRING_AGG, UPDATE ... FROM and DELETE ... USING; other constructs
+// are rejected or rewritten as their standard equivalent.
+func (d *StandardDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureRightJoin, FeatureFullJoin, FeatureLateral, FeatureJoinUsing, FeatureUpdateFrom, FeatureDeleteUsing,
+		FeatureGroupingSets, FeatureUnorderedLimit, FeatureDerivedColumnList, FeatureParenthesizedSetOperand,
+		FeatureNestedWith, FeatureRecursiveCTE, FeatureStringAgg, FeatureArrayAgg, FeatureAggregateOrderBy,
+		FeatureWithinGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+// FunctionName implements Dialect. The standard dialect uses the names as they are.
+func (d *StandardDialect) FunctionName(name string) string {
+	return name
+}
+
+// BoolLiteral implements Dialect with TRUE and FALSE.
+func (d *StandardDialect) BoolLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// StringLiteral implements Dialect by doubling single quotes.
+func (d *StandardDialect) StringLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ValuesRow implements Dialect with the values in parentheses.
+func (d *StandardDialect) ValuesRow(values []string) string {
+	return "(" + strings.Join(values, ", ") + ")"
+}
+
+// RenderOperator implements Dialect with the standard spelling of op between the operands.
+func (d *StandardDialect) RenderOperator(op Operator, operands []string) string {
+	return strings.Join(operands, " "+string(op)+" ")
+}
+
+// CastType implements Dialect with the SQL standard type names.
+func (d *StandardDialect) CastType(t reflect.Type) (string, bool) {
+	if t == reflect.TypeFor[time.Time]() {
+		return "TIMESTAMP", true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32:
+		return "INTEGER", true
+	case reflect.Int16, reflect.Int8:
+		return "SMALLINT", true
+	case reflect.Int64:
+		return "BIGINT", true
+	case reflect.Float32:
+		return "REAL", true
+	case reflect.Float64:
+		return "DOUBLE PRECISION", true
+	case reflect.String:
+		return "VARCHAR", true
+	case reflect.Bool:
+		return "BOOLEAN", true
+	default:
+		return "", false
+	}
+}
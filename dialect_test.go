@@ -1,6 +1,9 @@
 package tomasql
 
 import (
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,7 +11,7 @@ import (
 
 // TestStandardDialect_Name tests the Name method
 func TestStandardDialect_Name(t *testing.T) {
-	dialect := &standardDialect{}
+	dialect := &StandardDialect{}
 	require.Equal(t, "standard", dialect.Name())
 }
 
@@ -23,7 +26,7 @@ func TestStandardDialect_Placeholder(t *testing.T) {
 		{100, "?"},
 	}
 
-	dialect := &standardDialect{}
+	dialect := &StandardDialect{}
 	for _, tt := range tests {
 		t.Run("Position "+string(rune(tt.position)), func(t *testing.T) {
 			require.Equal(t, tt.expected, dialect.Placeholder(tt.position))
@@ -87,14 +90,14 @@ func TestDefaultDialect(t *testing.T) {
 	require.Equal(t, "?", DefaultDialect.Placeholder(1))
 }
 
-// TestDialect_InterfaceCompliance tests that standardDialect implements Dialect
+// TestDialect_InterfaceCompliance tests that StandardDialect implements Dialect
 func TestDialect_InterfaceCompliance(t *testing.T) {
-	var _ Dialect = (*standardDialect)(nil)
+	var _ Dialect = (*StandardDialect)(nil)
 }
 
 // TestDialect_Placeholder_IgnoresPosition tests that standard dialect ignores position
 func TestDialect_Placeholder_IgnoresPosition(t *testing.T) {
-	dialect := &standardDialect{}
+	dialect := &StandardDialect{}
 
 	for i := 1; i <= 10; i++ {
 		require.Equal(t, "?", dialect.Placeholder(i))
@@ -103,6 +106,7 @@ func TestDialect_Placeholder_IgnoresPosition(t *testing.T) {
 
 // customTestDialect is a test dialect for testing SetDialect
 type customTestDialect struct {
+	StandardDialect
 	name string
 }
 
@@ -115,3 +119,160 @@ func (d *customTestDialect) Name() string {
 func (d *customTestDialect) Placeholder(_ int) string {
 	return "custom"
 }
+
+// numberedTestDialect is a test dialect with Postgres-like numbered placeholders and a configurable set of features
+type numberedTestDialect struct {
+	StandardDialect
+	features []Feature
+}
+
+var _ Dialect = (*numberedTestDialect)(nil)
+
+func (d *numberedTestDialect) Name() string {
+	return "numbered"
+}
+
+func (d *numberedTestDialect) Placeholder(position int) string {
+	return fmt.Sprintf("$%d", position)
+}
+
+func (d *numberedTestDialect) NumberedPlaceholders() bool {
+	return true
+}
+
+func (d *numberedTestDialect) Supports(feature Feature) bool {
+	return slices.Contains(d.features, feature)
+}
+
+// withDialect sets d as the current dialect for the duration of the test
+func withDialect(t *testing.T, d Dialect) {
+	originalDialect := GetDialect()
+	t.Cleanup(func() { SetDialect(originalDialect) })
+	SetDialect(d)
+}
+
+func TestDialectSupports(t *testing.T) {
+	require.False(t, (&StandardDialect{}).Supports(FeatureReturning))
+	require.True(t, (&StandardDialect{}).Supports(FeatureFullJoin))
+	require.False(t, (&customTestDialect{name: "custom"}).Supports(FeatureReturning))
+	require.True(t, (&numberedTestDialect{features: []Feature{FeatureReturning}}).Supports(FeatureReturning))
+}
+
+func TestUnsupportedFeatureError(t *testing.T) {
+	err := &UnsupportedFeatureError{Dialect: "standard", Feature: FeatureReturning}
+	require.EqualError(t, err, "tomasql: RETURNING is not supported by the standard dialect")
+}
+
+func TestSQLFor(t *testing.T) {
+	withDialect(t, &StandardDialect{})
+	query := Select(Account.Id).From(Account).Where(Account.Uuid.EqParam("a").Or(Account.Type.EqParam("b")))
+
+	sql, params := query.SQLFor(&numberedTestDialect{})
+	require.Equal(t, "SELECT account.id FROM account WHERE account.uuid = $1 OR account.type = $2", sql)
+	require.Equal(t, []any{"a", "b"}, params)
+
+	// the default dialect is left untouched
+	require.Equal(t, "standard", GetDialect().Name())
+	sql, _ = query.SQL()
+	require.Equal(t, "SELECT account.id FROM account WHERE account.uuid = ? OR account.type = ?", sql)
+}
+
+func TestRenderWith(t *testing.T) {
+	withDialect(t, &StandardDialect{})
+	inner := Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip"))
+	query := Select(Config.Uuid).From(Config).Where(Config.AccountId.In(inner.AsSubQuery())).
+		RenderWith(&numberedTestDialect{})
+
+	sql, params := query.SQL()
+	require.Equal(t, "SELECT config.uuid FROM config WHERE config.account_id IN "+
+		"(SELECT account.id FROM account WHERE account.type = $1)", sql)
+	require.Equal(t, []any{"vip"}, params)
+
+	sql, _ = inner.RenderWith(&customTestDialect{}).SQL()
+	require.Equal(t, "SELECT account.id FROM account WHERE account.type = custom", sql)
+
+	sql, _ = query.RenderWith(&StandardDialect{}).SQL()
+	require.Equal(t, "SELECT config.uuid FROM config WHERE config.account_id IN "+
+		"(SELECT account.id FROM account WHERE account.type = ?)", sql)
+}
+
+func TestSQLFor_Concurrent(t *testing.T) {
+	withDialect(t, &StandardDialect{})
+	query := Select(Account.Id).From(Account).Where(Account.Id.EqParam(1)).OrderBy(Account.Id.Asc()).Limit(5)
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var d Dialect = &numberedTestDialect{}
+			want := "SELECT account.id FROM account WHERE account.id = $1 ORDER BY account.id ASC LIMIT 5"
+			if i%2 == 0 {
+				d = &customTestDialect{name: "custom"}
+				want = "SELECT account.id FROM account WHERE account.id = custom ORDER BY account.id ASC LIMIT 5"
+			}
+			sql, _ := query.SQLFor(d)
+			require.Equal(t, want, sql)
+		}()
+	}
+	wg.Wait()
+}
+
+// dialectProbe renders a nested query with its own SQL, recording the dialects it sees while rendered.
+type dialectProbe struct {
+	nested        SQLable
+	renderDialect string
+	globalDialect string
+}
+
+func (p *dialectProbe) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	p.renderDialect = params.Dialect().Name()
+	p.globalDialect = GetDialect().Name()
+	sql, _ := p.nested.SQL()
+	return "(" + sql + ")", params
+}
+
+func TestSQLFor_NestedRender(t *testing.T) {
+	withDialect(t, &StandardDialect{})
+	probe := &dialectProbe{nested: Select(Account.Id).From(Account).Where(Account.Id.EqParam(1))}
+
+	sql, _ := Select(probe).From(Config).SQLFor(&numberedTestDialect{})
+	require.Equal(t, "SELECT (SELECT account.id FROM account WHERE account.id = ?) FROM config", sql)
+	require.Equal(t, "numbered", probe.renderDialect)
+	require.Equal(t, "standard", probe.globalDialect)
+}
+
+func TestBuild(t *testing.T) {
+	withDialect(t, &StandardDialect{})
+
+	t.Run("renders the query", func(t *testing.T) {
+		sql, params, err := Select(Account.Id).From(Account).Where(Account.Id.EqParam(1)).Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT account.id FROM account WHERE account.id = ?", sql)
+		require.Equal(t, []any{int64(1)}, params)
+	})
+
+	t.Run("returns unsupported features", func(t *testing.T) {
+		query := DeleteFrom(Account).Where(Account.Id.EqParam(1)).Returning(Account.Id)
+
+		_, _, err := query.Build()
+		var unsupported *UnsupportedFeatureError
+		require.ErrorAs(t, err, &unsupported)
+		require.Equal(t, FeatureReturning, unsupported.Feature)
+
+		sql, _, err := query.RenderWith(&numberedTestDialect{features: []Feature{FeatureReturning}}).Build()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM account WHERE account.id = $1 RETURNING account.id", sql)
+	})
+
+	t.Run("returns invalid queries", func(t *testing.T) {
+		_, _, err := DeleteFrom(Account).Where(nil).Build()
+		require.ErrorIs(t, err, ErrUnconditionalDelete)
+	})
+
+	t.Run("does not recover misuse", func(t *testing.T) {
+		require.Panics(t, func() {
+			_, _, _ = Select(Case[int]().Else(Literal(1))).From(Account).Build()
+		})
+	})
+}
@@ -0,0 +1,51 @@
+// Package dialecttest provides the table and helpers shared by the tests of the dialect packages.
+package dialecttest
+
+import (
+	"testing"
+
+	"github.com/sergiobonfiglio/tomasql"
+)
+
+// UsersTable is a minimal table definition, like the ones generated by table-def-gen.
+type UsersTable struct {
+	alias  *string
+	Id     *tomasql.Col[int64]
+	Name   *tomasql.Col[string]
+	Active *tomasql.Col[bool]
+}
+
+func newUsersTable() *UsersTable {
+	t := &UsersTable{}
+	t.Id = tomasql.NewCol[int64]("id", t)
+	t.Name = tomasql.NewCol[string]("name", t)
+	t.Active = tomasql.NewCol[bool]("active", t)
+	return t
+}
+
+func (t *UsersTable) TableName() string {
+	return "users"
+}
+
+func (t *UsersTable) Alias() *string {
+	return t.alias
+}
+
+func (t *UsersTable) As(alias string) *UsersTable {
+	newT := newUsersTable()
+	newT.alias = &alias
+	return newT
+}
+
+func (t *UsersTable) SqlWithParams(params *tomasql.ParamsMap, ctx tomasql.RenderContext) (string, *tomasql.ParamsMap) {
+	return tomasql.NewSqlableTable(t).SqlWithParams(params, ctx)
+}
+
+var Users = newUsersTable()
+
+// WithDialect sets d as the current dialect for the duration of the test.
+func WithDialect(t *testing.T, d tomasql.Dialect) {
+	originalDialect := tomasql.GetDialect()
+	t.Cleanup(func() { tomasql.SetDialect(originalDialect) })
+	tomasql.SetDialect(d)
+}
@@ -0,0 +1,15 @@
+package mssql
+
+import "github.com/sergiobonfiglio/tomasql"
+
+var mssqlDialect *MSSQLDialect = &MSSQLDialect{}
+
+func GetDialect() *MSSQLDialect {
+	return mssqlDialect
+}
+
+// SetDialect sets the SQL Server dialect as the current tomasql dialect.
+// Equivalent to calling tomasql.SetDialect(&MSSQLDialect{}).
+func SetDialect() {
+	tomasql.SetDialect(&MSSQLDialect{})
+}
@@ -0,0 +1,127 @@
+package mssql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sergiobonfiglio/tomasql"
+)
+
+// MSSQLDialect renders queries for SQL Server 2022 or later.
+type MSSQLDialect struct {
+	tomasql.StandardDialect
+}
+
+var _ tomasql.Dialect = (*MSSQLDialect)(nil)
+
+func (m *MSSQLDialect) Name() string {
+	return "mssql"
+}
+
+// Placeholder implements tomasql.Dialect with the @p1, @p2, ... named parameters of the SQL Server drivers.
+func (m *MSSQLDialect) Placeholder(position int) string {
+	return fmt.Sprintf("@p%d", position)
+}
+
+// NumberedPlaceholders implements tomasql.Dialect.
+func (m *MSSQLDialect) NumberedPlaceholders() bool {
+	return true
+}
+
+// Supports implements tomasql.Dialect. SQL Server locks rows with table hints, deletes with DELETE ... FROM and has
+// its own OUTPUT and MERGE statements, which tomasql does not render.
+func (m *MSSQLDialect) Supports(feature tomasql.Feature) bool {
+	switch feature {
+	case tomasql.FeatureRightJoin, tomasql.FeatureFullJoin, tomasql.FeatureUpdateFrom, tomasql.FeatureGroupingSets,
+		tomasql.FeatureDerivedColumnList, tomasql.FeatureParenthesizedSetOperand, tomasql.FeatureRecursiveCTE,
+		tomasql.FeatureStringAgg:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuoteIdentifier implements tomasql.Dialect with square brackets.
+func (m *MSSQLDialect) QuoteIdentifier(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+// WithRecursive implements tomasql.Dialect: SQL Server has no RECURSIVE keyword, any CTE can reference itself.
+func (m *MSSQLDialect) WithRecursive() string {
+	return "WITH"
+}
+
+// Top implements tomasql.Dialect.
+func (m *MSSQLDialect) Top(limit int) (string, bool) {
+	return fmt.Sprintf("TOP %d", limit), true
+}
+
+// LimitOffset implements tomasql.Dialect with OFFSET ... FETCH, which SQL Server only accepts after an
+// ORDER BY. A limit without offset is only rendered here when it applies to a set operation, as TOP cannot express it.
+func (m *MSSQLDialect) LimitOffset(limit, offset *int) string {
+	skip := 0
+	if offset != nil {
+		skip = *offset
+	}
+	if limit == nil {
+		return fmt.Sprintf("OFFSET %d ROWS", skip)
+	}
+	return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", skip, *limit)
+}
+
+// BoolLiteral implements tomasql.Dialect. SQL Server has no boolean literals, only BIT values.
+func (m *MSSQLDialect) BoolLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// RenderOperator implements tomasql.Dialect: SQL Server concatenates strings with + or CONCAT.
+func (m *MSSQLDialect) RenderOperator(op tomasql.Operator, operands []string) string {
+	if op == tomasql.OperatorConcat {
+		return "CONCAT(" + strings.Join(operands, ", ") + ")"
+	}
+	return m.StandardDialect.RenderOperator(op, operands)
+}
+
+// FunctionName implements tomasql.Dialect with the names of the SQL Server functions that differ from the standard
+// ones.
+func (m *MSSQLDialect) FunctionName(name string) string {
+	if name == "LENGTH" {
+		return "LEN"
+	}
+	return name
+}
+
+// CastType implements tomasql.Dialect.
+func (m *MSSQLDialect) CastType(t reflect.Type) (string, bool) {
+	if t == reflect.TypeFor[time.Time]() {
+		return "DATETIME2", true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BIT", true
+	case reflect.Uint8:
+		return "TINYINT", true
+	case reflect.Int8, reflect.Int16:
+		return "SMALLINT", true
+	case reflect.Int32:
+		return "INT", true
+	case reflect.Int, reflect.Int64:
+		return "BIGINT", true
+	case reflect.Float32:
+		return "REAL", true
+	case reflect.Float64:
+		return "FLOAT", true
+	case reflect.String:
+		return "NVARCHAR(MAX)", true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "VARBINARY(MAX)", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,344 @@
+package mssql
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/internal/dialecttest"
+)
+
+var Users = dialecttest.Users
+
+func TestMSSQLDialectName(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	if got := dialect.Name(); got != "mssql" {
+		t.Errorf("Name() = %q, want %q", got, "mssql")
+	}
+}
+
+func TestMSSQLDialectPlaceholder(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	tests := map[int]string{
+		1:   "@p1",
+		2:   "@p2",
+		100: "@p100",
+	}
+
+	for position, want := range tests {
+		if got := dialect.Placeholder(position); got != want {
+			t.Errorf("Placeholder(%d) = %q, want %q", position, got, want)
+		}
+	}
+}
+
+func TestGetDialect(t *testing.T) {
+	dialect := GetDialect()
+	if dialect == nil {
+		t.Fatal("GetDialect() returned nil")
+	}
+	if dialect.Name() != "mssql" {
+		t.Errorf("GetDialect().Name() = %q, want %q", dialect.Name(), "mssql")
+	}
+}
+
+func TestSetDialect(t *testing.T) {
+	dialecttest.WithDialect(t, tomasql.DefaultDialect)
+	SetDialect()
+
+	if got := tomasql.GetDialect().Name(); got != "mssql" {
+		t.Errorf("After SetDialect(), dialect name = %q, want %q", got, "mssql")
+	}
+}
+
+func TestMSSQLDialectSupports(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	tests := []struct {
+		feature tomasql.Feature
+		want    bool
+	}{
+		{tomasql.FeatureRightJoin, true},
+		{tomasql.FeatureFullJoin, true},
+		{tomasql.FeatureGroupingSets, true},
+		{tomasql.FeatureLateral, false},
+		{tomasql.FeatureReturning, false},
+		{tomasql.FeatureOnConflict, false},
+		{tomasql.FeatureForUpdate, false},
+		{tomasql.FeatureFilter, false},
+		{tomasql.FeatureStringAgg, true},
+		{tomasql.FeatureAggregateOrderBy, false},
+		{tomasql.FeatureRecursiveCTE, true},
+		{tomasql.FeatureNestedWith, false},
+		{tomasql.FeatureWithinGroup, false},
+	}
+
+	for _, tt := range tests {
+		if got := dialect.Supports(tt.feature); got != tt.want {
+			t.Errorf("Supports(%q) = %v, want %v", tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestMSSQLDialectQuoteIdentifier(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	tests := map[string]string{
+		"users":     "[users]",
+		"order":     "[order]",
+		"weird]col": "[weird]]col]",
+	}
+
+	for name, want := range tests {
+		if got := dialect.QuoteIdentifier(name); got != want {
+			t.Errorf("QuoteIdentifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMSSQLDialectLimitOffset(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	ten, twenty := 10, 20
+	tests := []struct {
+		limit, offset *int
+		want          string
+	}{
+		{&ten, nil, "OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{&ten, &twenty, "OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{nil, &twenty, "OFFSET 20 ROWS"},
+	}
+
+	for _, tt := range tests {
+		if got := dialect.LimitOffset(tt.limit, tt.offset); got != tt.want {
+			t.Errorf("LimitOffset() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMSSQLDialectCastType(t *testing.T) {
+	dialect := &MSSQLDialect{}
+	tests := []struct {
+		typ    reflect.Type
+		want   string
+		wantOk bool
+	}{
+		{reflect.TypeFor[int](), "BIGINT", true},
+		{reflect.TypeFor[int32](), "INT", true},
+		{reflect.TypeFor[bool](), "BIT", true},
+		{reflect.TypeFor[float64](), "FLOAT", true},
+		{reflect.TypeFor[string](), "NVARCHAR(MAX)", true},
+		{reflect.TypeFor[time.Time](), "DATETIME2", true},
+		{reflect.TypeFor[[]byte](), "VARBINARY(MAX)", true},
+		{reflect.TypeFor[map[string]int](), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			got, ok := dialect.CastType(tt.typ)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CastType(%s) = %q, %v, want %q, %v", tt.typ, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMSSQLQueries(t *testing.T) {
+	dialecttest.WithDialect(t, &MSSQLDialect{})
+
+	tests := []struct {
+		name       string
+		query      tomasql.SQLable
+		wantSql    string
+		wantParams []any
+	}{
+		{
+			name: "top",
+			query: tomasql.Select(Users.Id, Users.Name.As("user_name")).From(Users).
+				Where(Users.Name.LikeParam("a%").And(Users.Active.Eq(tomasql.Literal(true)))).
+				OrderBy(Users.Name.Asc()).
+				Limit(10),
+			wantSql: "SELECT TOP 10 [users].[id], [users].[name] AS [user_name] FROM [users] " +
+				"WHERE [users].[name] LIKE @p1 AND [users].[active] = 1 ORDER BY [users].[name] ASC",
+			wantParams: []any{"a%"},
+		},
+		{
+			name:    "distinct top",
+			query:   tomasql.SelectDistinct(Users.Name).From(Users).OrderBy(Users.Name.Asc()).Limit(5),
+			wantSql: "SELECT DISTINCT TOP 5 [users].[name] FROM [users] ORDER BY [users].[name] ASC",
+		},
+		{
+			name:       "select all top",
+			query:      tomasql.SelectAll().From(Users).Where(Users.Id.GtParam(1)).OrderBy(Users.Id.Asc()).Limit(1),
+			wantSql:    "SELECT TOP 1 * FROM [users] WHERE [users].[id] > @p1 ORDER BY [users].[id] ASC",
+			wantParams: []any{int64(1)},
+		},
+		{
+			name: "offset fetch",
+			query: tomasql.Select(Users.Id).From(Users).
+				OrderBy(Users.Id.Desc()).
+				Limit(10).
+				Offset(20),
+			wantSql: "SELECT [users].[id] FROM [users] ORDER BY [users].[id] DESC " +
+				"OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name: "limited set operation",
+			query: tomasql.Select(Users.Id).From(Users).
+				Union(tomasql.Select(Users.Id).From(Users)).
+				OrderBy(Users.Id.Asc()).
+				Limit(3),
+			wantSql: "SELECT [users].[id] FROM [users] UNION SELECT [users].[id] FROM [users] " +
+				"ORDER BY [id] ASC OFFSET 0 ROWS FETCH NEXT 3 ROWS ONLY",
+		},
+		{
+			name: "limited subquery",
+			query: tomasql.Select(Users.Name).From(Users).
+				Where(Users.Id.In(tomasql.Select(Users.Id).From(Users).OrderBy(Users.Id.Desc()).Limit(2).AsSubQuery())),
+			wantSql: "SELECT [users].[name] FROM [users] WHERE [users].[id] IN " +
+				"(SELECT TOP 2 [users].[id] FROM [users] ORDER BY [users].[id] DESC)",
+		},
+		{
+			name:    "function names",
+			query:   tomasql.Select(tomasql.Length(Users.Name)).From(Users).GroupBy(tomasql.Rollup(Users.Name)),
+			wantSql: "SELECT LEN([users].[name]) FROM [users] GROUP BY ROLLUP ([users].[name])",
+		},
+		{
+			name:    "concat",
+			query:   tomasql.Select(tomasql.Concat(Users.Name, tomasql.Literal("!"))).From(Users),
+			wantSql: "SELECT CONCAT([users].[name], '!') FROM [users]",
+		},
+		{
+			name:       "update",
+			query:      tomasql.Update(Users).Set(Users.Active.SetParam(true)).Where(Users.Id.EqParam(1)),
+			wantSql:    "UPDATE [users] SET [active] = @p1 WHERE [users].[id] = @p2",
+			wantParams: []any{true, int64(1)},
+		},
+		{
+			name: "recursive cte",
+			query: func() tomasql.SQLable {
+				tree := tomasql.WithRecursive("tree", tomasql.Select(Users.Id).From(Users))
+				tree.UnionAll(tomasql.Select(Users.Id).From(Users).
+					Join(tree).On(Users.Id.Eq(tomasql.DerivedCol(tree, Users.Id))))
+				return tomasql.Select(tomasql.Count()).From(tree)
+			}(),
+			wantSql: "WITH [tree] AS (SELECT [users].[id] FROM [users] UNION ALL SELECT [users].[id] FROM [users] " +
+				"JOIN [tree] ON [users].[id] = [tree].[id]) SELECT COUNT(1) FROM [tree]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSql, gotParams := tt.query.SQL()
+			if gotSql != tt.wantSql {
+				t.Errorf("SQL() =\n%s\nwant\n%s", gotSql, tt.wantSql)
+			}
+			if len(gotParams) != len(tt.wantParams) || (len(gotParams) > 0 && !reflect.DeepEqual(gotParams, tt.wantParams)) {
+				t.Errorf("params = %v, want %v", gotParams, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestMSSQLDoesNotKeepTop(t *testing.T) {
+	dialecttest.WithDialect(t, &MSSQLDialect{})
+
+	query := tomasql.Select(Users.Id).From(Users)
+	limited := query.OrderBy(Users.Id.Asc()).Limit(1)
+	if got, _ := limited.SQL(); got != "SELECT TOP 1 [users].[id] FROM [users] ORDER BY [users].[id] ASC" {
+		t.Errorf("SQL() = %s", got)
+	}
+	// the TOP belongs to the render of the limited query, not to the shared SELECT
+	if got, _ := query.SQL(); got != "SELECT [users].[id] FROM [users]" {
+		t.Errorf("SQL() = %s, want no TOP", got)
+	}
+}
+
+func TestMSSQLTopConcurrentRenders(t *testing.T) {
+	query := tomasql.Select(Users.Id).From(Users).OrderBy(Users.Id.Asc()).Limit(1)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var d tomasql.Dialect = GetDialect()
+			want := "SELECT TOP 1 [users].[id] FROM [users] ORDER BY [users].[id] ASC"
+			if i%2 == 0 {
+				d = tomasql.DefaultDialect
+				want = "SELECT users.id FROM users ORDER BY users.id ASC LIMIT 1"
+			}
+			if got, _ := query.SQLFor(d); got != want {
+				t.Errorf("SQLFor() = %s, want %s", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMSSQLRejectsUnorderedLimit(t *testing.T) {
+	dialecttest.WithDialect(t, &MSSQLDialect{})
+
+	defer func() {
+		err, ok := recover().(*tomasql.UnsupportedFeatureError)
+		if !ok {
+			t.Fatalf("expected an *UnsupportedFeatureError panic, got %v", err)
+		}
+		if err.Feature != tomasql.FeatureUnorderedLimit {
+			t.Errorf("Feature = %q, want %q", err.Feature, tomasql.FeatureUnorderedLimit)
+		}
+	}()
+
+	// OFFSET ... FETCH needs an ORDER BY, and a set operation cannot use TOP
+	tomasql.Select(Users.Id).From(Users).Union(tomasql.Select(Users.Id).From(Users)).Limit(3).SQL()
+}
+
+func TestMSSQLUnsupportedFeatures(t *testing.T) {
+	dialecttest.WithDialect(t, &MSSQLDialect{})
+
+	tests := []struct {
+		name    string
+		feature tomasql.Feature
+		query   func() tomasql.SQLable
+	}{
+		{"ordered string agg", tomasql.FeatureAggregateOrderBy, func() tomasql.SQLable {
+			return tomasql.Select(tomasql.StringAgg(Users.Name, ", ").OrderBy(Users.Name.Asc())).From(Users)
+		}},
+		{"array agg", tomasql.FeatureArrayAgg, func() tomasql.SQLable {
+			return tomasql.Select(tomasql.ArrayAgg[int64](Users.Id)).From(Users)
+		}},
+		{"within group", tomasql.FeatureWithinGroup, func() tomasql.SQLable {
+			return tomasql.Select(tomasql.PercentileCont(0.5).WithinGroup(Users.Id.Asc())).From(Users)
+		}},
+		{"join using", tomasql.FeatureJoinUsing, func() tomasql.SQLable {
+			return tomasql.Select(Users.Id).From(Users).Join(Users.As("u2")).Using(Users.Id)
+		}},
+		{"cte in a subquery", tomasql.FeatureNestedWith, func() tomasql.SQLable {
+			active := tomasql.With("active", tomasql.Select(Users.Id).From(Users).Where(Users.Active.Eq(tomasql.Literal(true))))
+			return tomasql.Select(Users.Name).From(Users).
+				Where(Users.Id.In(tomasql.Select(tomasql.DerivedCol(active, Users.Id)).From(active).AsSubQuery()))
+		}},
+		{"cte in a set operation", tomasql.FeatureNestedWith, func() tomasql.SQLable {
+			active := tomasql.With("active", tomasql.Select(Users.Id).From(Users).Where(Users.Active.Eq(tomasql.Literal(true))))
+			return tomasql.Select(Users.Id).From(Users).
+				Union(tomasql.Select(tomasql.DerivedCol(active, Users.Id)).From(active))
+		}},
+		{"delete using", tomasql.FeatureDeleteUsing, func() tomasql.SQLable {
+			return tomasql.DeleteFrom(Users).Using(Users.As("u2")).Where(Users.As("u2").Id.Eq(Users.Id))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.query().Build()
+
+			var unsupported *tomasql.UnsupportedFeatureError
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("Build() error = %v, want an *UnsupportedFeatureError", err)
+			}
+			if unsupported.Feature != tt.feature {
+				t.Errorf("Feature = %q, want %q", unsupported.Feature, tt.feature)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package mysql
+
+import "github.com/sergiobonfiglio/tomasql"
+
+var mysqlDialect *MySQLDialect = &MySQLDialect{}
+
+func GetDialect() *MySQLDialect {
+	return mysqlDialect
+}
+
+// SetDialect sets the MySQL dialect as the current tomasql dialect.
+// Equivalent to calling tomasql.SetDialect(&MySQLDialect{}).
+func SetDialect() {
+	tomasql.SetDialect(&MySQLDialect{})
+}
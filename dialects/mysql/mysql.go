@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sergiobonfiglio/tomasql"
+)
+
+// MySQLDialect renders queries for MySQL 8.0.31 or later, the first version with INTERSECT and EXCEPT.
+type MySQLDialect struct {
+	tomasql.StandardDialect
+}
+
+var _ tomasql.Dialect = (*MySQLDialect)(nil)
+
+// maxRows is the row count used for an OFFSET without LIMIT, which MySQL cannot express otherwise.
+const maxRows = "18446744073709551615"
+
+func (m *MySQLDialect) Name() string {
+	return "mysql"
+}
+
+// Placeholder implements tomasql.Dialect. MySQL placeholders are positional, so the position is not rendered.
+func (m *MySQLDialect) Placeholder(_ int) string {
+	return "?"
+}
+
+// Supports implements tomasql.Dialect. MySQL joins the tables of multi-table UPDATE and DELETE statements with its
+// own syntax, which tomasql does not render.
+func (m *MySQLDialect) Supports(feature tomasql.Feature) bool {
+	switch feature {
+	case tomasql.FeatureOnDuplicateKey, tomasql.FeatureRightJoin, tomasql.FeatureLateral, tomasql.FeatureJoinUsing,
+		tomasql.FeatureUnorderedLimit,
+		tomasql.FeatureDerivedColumnList, tomasql.FeatureParenthesizedSetOperand, tomasql.FeatureNestedWith,
+		tomasql.FeatureRecursiveCTE, tomasql.FeatureForUpdate, tomasql.FeatureForShare, tomasql.FeatureSkipLocked, tomasql.FeatureNoWait:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuoteIdentifier implements tomasql.Dialect with backticks.
+func (m *MySQLDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// LimitOffset implements tomasql.Dialect with the LIMIT offset, count syntax.
+func (m *MySQLDialect) LimitOffset(limit, offset *int) string {
+	switch {
+	case offset == nil:
+		return fmt.Sprintf("LIMIT %d", *limit)
+	case limit == nil:
+		return fmt.Sprintf("LIMIT %d, %s", *offset, maxRows)
+	default:
+		return fmt.Sprintf("LIMIT %d, %d", *offset, *limit)
+	}
+}
+
+// BoolLiteral implements tomasql.Dialect. MySQL booleans are TINYINT(1) values.
+func (m *MySQLDialect) BoolLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// StringLiteral implements tomasql.Dialect. A backslash starts an escape sequence in MySQL strings, so it is escaped
+// along with single quotes. This assumes the default SQL mode: with NO_BACKSLASH_ESCAPES, escaped backslashes would
+// be read twice.
+func (m *MySQLDialect) StringLiteral(value string) string {
+	return "'" + literalEscaper.Replace(value) + "'"
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, "'", "''")
+
+// ValuesRow implements tomasql.Dialect: the rows of a VALUES table are ROW(...) constructors in MySQL.
+func (m *MySQLDialect) ValuesRow(values []string) string {
+	return "ROW(" + strings.Join(values, ", ") + ")"
+}
+
+// RenderOperator implements tomasql.Dialect: || is a logical OR in MySQL, and <=> is its null-safe equality.
+func (m *MySQLDialect) RenderOperator(op tomasql.Operator, operands []string) string {
+	switch op {
+	case tomasql.OperatorConcat:
+		return "CONCAT(" + strings.Join(operands, ", ") + ")"
+	case tomasql.OperatorNotDistinctFrom:
+		return operands[0] + " <=> " + operands[1]
+	case tomasql.OperatorDistinctFrom:
+		return "NOT (" + operands[0] + " <=> " + operands[1] + ")"
+	default:
+		return m.StandardDialect.RenderOperator(op, operands)
+	}
+}
+
+// FunctionName implements tomasql.Dialect. LENGTH counts bytes in MySQL, CHAR_LENGTH counts characters like the
+// LENGTH of the other databases.
+func (m *MySQLDialect) FunctionName(name string) string {
+	if name == "LENGTH" {
+		return "CHAR_LENGTH"
+	}
+	return name
+}
+
+// CastType implements tomasql.Dialect. MySQL only casts to a few types, e.g. SIGNED instead of INTEGER.
+func (m *MySQLDialect) CastType(t reflect.Type) (string, bool) {
+	if t == reflect.TypeFor[time.Time]() {
+		return "DATETIME", true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Bool:
+		return "SIGNED", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "UNSIGNED", true
+	case reflect.Float32:
+		return "FLOAT", true
+	case reflect.Float64:
+		return "DOUBLE", true
+	case reflect.String:
+		return "CHAR", true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BINARY", true
+		}
+	}
+	return "", false
+}
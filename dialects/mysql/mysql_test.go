@@ -0,0 +1,384 @@
+package mysql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/internal/dialecttest"
+)
+
+var Users = dialecttest.Users
+
+func TestMySQLDialectName(t *testing.T) {
+	dialect := &MySQLDialect{}
+	if got := dialect.Name(); got != "mysql" {
+		t.Errorf("Name() = %q, want %q", got, "mysql")
+	}
+}
+
+func TestMySQLDialectPlaceholder(t *testing.T) {
+	dialect := &MySQLDialect{}
+	for _, position := range []int{0, 1, 2, 100} {
+		if got := dialect.Placeholder(position); got != "?" {
+			t.Errorf("Placeholder(%d) = %q, want %q", position, got, "?")
+		}
+	}
+}
+
+func TestGetDialect(t *testing.T) {
+	dialect := GetDialect()
+	if dialect == nil {
+		t.Fatal("GetDialect() returned nil")
+	}
+	if dialect.Name() != "mysql" {
+		t.Errorf("GetDialect().Name() = %q, want %q", dialect.Name(), "mysql")
+	}
+}
+
+func TestSetDialect(t *testing.T) {
+	dialecttest.WithDialect(t, tomasql.DefaultDialect)
+	SetDialect()
+
+	if got := tomasql.GetDialect().Name(); got != "mysql" {
+		t.Errorf("After SetDialect(), dialect name = %q, want %q", got, "mysql")
+	}
+}
+
+func TestMySQLDialectSupports(t *testing.T) {
+	dialect := &MySQLDialect{}
+	tests := []struct {
+		feature tomasql.Feature
+		want    bool
+	}{
+		{tomasql.FeatureOnDuplicateKey, true},
+		{tomasql.FeatureForUpdate, true},
+		{tomasql.FeatureSkipLocked, true},
+		{tomasql.FeatureRightJoin, true},
+		{tomasql.FeatureFullJoin, false},
+		{tomasql.FeatureOnConflict, false},
+		{tomasql.FeatureReturning, false},
+		{tomasql.FeatureFilter, false},
+		{tomasql.FeatureGroupingSets, false},
+		{tomasql.FeatureRecursiveCTE, true},
+		{tomasql.FeatureNestedWith, true},
+		{tomasql.FeatureStringAgg, false},
+		{tomasql.FeatureArrayAgg, false},
+		{tomasql.FeatureWithinGroup, false},
+		{tomasql.FeatureJoinUsing, true},
+		{tomasql.FeatureUpdateFrom, false},
+		{tomasql.FeatureDeleteUsing, false},
+	}
+
+	for _, tt := range tests {
+		if got := dialect.Supports(tt.feature); got != tt.want {
+			t.Errorf("Supports(%q) = %v, want %v", tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestMySQLDialectQuoteIdentifier(t *testing.T) {
+	dialect := &MySQLDialect{}
+	tests := map[string]string{
+		"users":     "`users`",
+		"order":     "`order`",
+		"weird`col": "`weird``col`",
+	}
+
+	for name, want := range tests {
+		if got := dialect.QuoteIdentifier(name); got != want {
+			t.Errorf("QuoteIdentifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMySQLDialectLimitOffset(t *testing.T) {
+	dialect := &MySQLDialect{}
+	ten, twenty := 10, 20
+	tests := []struct {
+		limit, offset *int
+		want          string
+	}{
+		{&ten, nil, "LIMIT 10"},
+		{&ten, &twenty, "LIMIT 20, 10"},
+		{nil, &twenty, "LIMIT 20, 18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		if got := dialect.LimitOffset(tt.limit, tt.offset); got != tt.want {
+			t.Errorf("LimitOffset() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMySQLDialectCastType(t *testing.T) {
+	dialect := &MySQLDialect{}
+	tests := []struct {
+		typ    reflect.Type
+		want   string
+		wantOk bool
+	}{
+		{reflect.TypeFor[int](), "SIGNED", true},
+		{reflect.TypeFor[int64](), "SIGNED", true},
+		{reflect.TypeFor[uint32](), "UNSIGNED", true},
+		{reflect.TypeFor[float64](), "DOUBLE", true},
+		{reflect.TypeFor[string](), "CHAR", true},
+		{reflect.TypeFor[time.Time](), "DATETIME", true},
+		{reflect.TypeFor[[]byte](), "BINARY", true},
+		{reflect.TypeFor[map[string]int](), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			got, ok := dialect.CastType(tt.typ)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CastType(%s) = %q, %v, want %q, %v", tt.typ, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMySQLQueries(t *testing.T) {
+	dialecttest.WithDialect(t, &MySQLDialect{})
+
+	valuesTable, _ := tomasql.Values(
+		tomasql.Row(tomasql.Param(1), tomasql.Literal("a")),
+		tomasql.Row(tomasql.Param(2), tomasql.Literal("b")),
+	).As("v", Users.Id, Users.Name)
+
+	tests := []struct {
+		name       string
+		query      tomasql.SQLable
+		wantSql    string
+		wantParams []any
+	}{
+		{
+			name: "select with quoting and limit",
+			query: tomasql.Select(Users.Id, Users.Name.As("user_name")).From(Users).
+				Where(Users.Name.LikeParam("a%").And(Users.Active.Eq(tomasql.Literal(true)))).
+				OrderBy(Users.Name.Asc()).
+				Limit(10).
+				Offset(20),
+			wantSql: "SELECT `users`.`id`, `users`.`name` AS `user_name` FROM `users` " +
+				"WHERE `users`.`name` LIKE ? AND `users`.`active` = 1 " +
+				"ORDER BY `users`.`name` ASC LIMIT 20, 10",
+			wantParams: []any{"a%"},
+		},
+		{
+			name: "aliased join and star",
+			query: tomasql.Select(Users.Id, tomasql.Count().As("total")).From(Users).
+				Join(Users.As("u2")).On(Users.As("u2").Id.Eq(Users.Id)).
+				GroupBy(Users.Id).
+				OrderBy(tomasql.Count().As("total").Desc()),
+			wantSql: "SELECT `users`.`id`, COUNT(1) AS `total` FROM `users` " +
+				"JOIN `users` AS `u2` ON `u2`.`id` = `users`.`id` GROUP BY `users`.`id` ORDER BY `total` DESC",
+		},
+		{
+			name: "null-safe comparisons and concat",
+			query: tomasql.Select(tomasql.Concat(Users.Name, tomasql.Literal("!"))).From(Users).
+				Where(Users.Name.IsNotDistinctFromParam("bob").Or(Users.Id.IsDistinctFrom(tomasql.Param(int64(1))))),
+			wantSql: "SELECT CONCAT(`users`.`name`, '!') FROM `users` " +
+				"WHERE `users`.`name` <=> ? OR NOT (`users`.`id` <=> ?)",
+			wantParams: []any{"bob", int64(1)},
+		},
+		{
+			name:    "function names",
+			query:   tomasql.Select(tomasql.Length(Users.Name), tomasql.Upper(Users.Name)).From(Users),
+			wantSql: "SELECT CHAR_LENGTH(`users`.`name`), UPPER(`users`.`name`) FROM `users`",
+		},
+		{
+			name:    "cast",
+			query:   tomasql.Select(tomasql.Cast[int](Users.Name)).From(Users),
+			wantSql: "SELECT CAST(`users`.`name` AS SIGNED) FROM `users`",
+		},
+		{
+			name: "filtered aggregate",
+			query: tomasql.Select(tomasql.Count().Filter(Users.Active.Eq(tomasql.Literal(false)))).
+				From(Users),
+			wantSql: "SELECT COUNT(CASE WHEN `users`.`active` = 0 THEN 1 END) FROM `users`",
+		},
+		{
+			name: "locking",
+			query: tomasql.Select(Users.Id).From(Users).Where(Users.Active.IsNull()).
+				OrderBy(Users.Id.Asc()).
+				Limit(1).
+				ForUpdate().SkipLocked(),
+			wantSql: "SELECT `users`.`id` FROM `users` WHERE `users`.`active` IS NULL ORDER BY `users`.`id` ASC LIMIT 1 FOR UPDATE SKIP LOCKED",
+		},
+		{
+			name: "on duplicate key update",
+			query: tomasql.InsertInto(Users).
+				Columns(Users.Id, Users.Name).
+				Values(Users.Id.Value(1), Users.Name.Value("bob")).
+				OnConflict(Users.Id).
+				DoUpdateSet(Users.Name.Set(Users.Name.Excluded())),
+			wantSql: "INSERT INTO `users` (`id`, `name`) VALUES (?, ?) AS `EXCLUDED` " +
+				"ON DUPLICATE KEY UPDATE `name` = `EXCLUDED`.`name`",
+			wantParams: []any{int64(1), "bob"},
+		},
+		{
+			name: "on duplicate key do nothing",
+			query: tomasql.InsertInto(Users).
+				Columns(Users.Id).
+				Values(Users.Id.Value(1)).
+				OnConflict().
+				DoNothing(),
+			wantSql:    "INSERT INTO `users` (`id`) VALUES (?) ON DUPLICATE KEY UPDATE `id` = `id`",
+			wantParams: []any{int64(1)},
+		},
+		{
+			name:       "update",
+			query:      tomasql.Update(Users).Set(Users.Active.SetParam(true)).Where(Users.Id.EqParam(1)),
+			wantSql:    "UPDATE `users` SET `active` = ? WHERE `users`.`id` = ?",
+			wantParams: []any{true, int64(1)},
+		},
+		{
+			name:    "string literal",
+			query:   tomasql.Select(Users.Id).From(Users).Where(Users.Name.Eq(tomasql.Literal(`\' OR 1=1 -- `))),
+			wantSql: "SELECT `users`.`id` FROM `users` WHERE `users`.`name` = '\\\\'' OR 1=1 -- '",
+		},
+		{
+			name:       "values list",
+			query:      tomasql.Select(tomasql.Count()).From(valuesTable),
+			wantSql:    "SELECT COUNT(1) FROM (VALUES ROW(CAST(? AS SIGNED), 'a'), ROW(?, 'b')) AS `v` (`id`, `name`)",
+			wantParams: []any{1, 2},
+		},
+		{
+			name:       "reused param",
+			query:      tomasql.Select(Users.Name).From(Users).Where(Users.Id.EqParam(1).Or(Users.Id.GtParam(1))),
+			wantSql:    "SELECT `users`.`name` FROM `users` WHERE `users`.`id` = ? OR `users`.`id` > ?",
+			wantParams: []any{int64(1), int64(1)},
+		},
+		{
+			name:       "reused in params",
+			query:      tomasql.Select(Users.Name).From(Users).Where(Users.Id.InParams(2, 2, 3)),
+			wantSql:    "SELECT `users`.`name` FROM `users` WHERE `users`.`id` IN (?, ?, ?)",
+			wantParams: []any{int64(2), int64(2), int64(3)},
+		},
+		{
+			name: "reused insert values",
+			query: tomasql.InsertInto(Users).
+				Columns(Users.Id, Users.Name).
+				Values(Users.Id.Value(1), Users.Name.Value("bob")).
+				Values(Users.Id.Value(2), Users.Name.Value("bob")),
+			wantSql:    "INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?)",
+			wantParams: []any{int64(1), "bob", int64(2), "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSql, gotParams := tt.query.SQL()
+			if gotSql != tt.wantSql {
+				t.Errorf("SQL() =\n%s\nwant\n%s", gotSql, tt.wantSql)
+			}
+			if len(gotParams) != len(tt.wantParams) || (len(gotParams) > 0 && !reflect.DeepEqual(gotParams, tt.wantParams)) {
+				t.Errorf("params = %v, want %v", gotParams, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestMySQLRejectsReturning(t *testing.T) {
+	dialecttest.WithDialect(t, &MySQLDialect{})
+
+	defer func() {
+		err, ok := recover().(*tomasql.UnsupportedFeatureError)
+		if !ok {
+			t.Fatalf("expected an *UnsupportedFeatureError panic, got %v", err)
+		}
+		if err.Feature != tomasql.FeatureReturning {
+			t.Errorf("Feature = %q, want %q", err.Feature, tomasql.FeatureReturning)
+		}
+	}()
+	tomasql.DeleteFrom(Users).Where(Users.Id.EqParam(1)).Returning(Users.Id).SQL()
+}
+
+func TestMySQLBuildRejectsStringAgg(t *testing.T) {
+	dialecttest.WithDialect(t, &MySQLDialect{})
+
+	sql, params, err := tomasql.Select(tomasql.StringAgg(Users.Name, ", ")).From(Users).Build()
+	if err == nil {
+		t.Fatalf("Build() = %q, %v, want an error", sql, params)
+	}
+	if want := "tomasql: STRING_AGG is not supported by the mysql dialect"; err.Error() != want {
+		t.Errorf("Build() error = %q, want %q", err, want)
+	}
+}
+
+func TestMySQLUnsupportedFeatures(t *testing.T) {
+	dialecttest.WithDialect(t, &MySQLDialect{})
+
+	tests := []struct {
+		name    string
+		feature tomasql.Feature
+		query   func() tomasql.SQLable
+	}{
+		{"delete using", tomasql.FeatureDeleteUsing, func() tomasql.SQLable {
+			return tomasql.DeleteFrom(Users).Using(Users.As("u2")).Where(Users.As("u2").Id.Eq(Users.Id))
+		}},
+		{"update from", tomasql.FeatureUpdateFrom, func() tomasql.SQLable {
+			return tomasql.Update(Users).Set(Users.Name.Set(Users.As("u2").Name)).From(Users.As("u2")).
+				Where(Users.As("u2").Id.Eq(Users.Id))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.query().Build()
+
+			var unsupported *tomasql.UnsupportedFeatureError
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("Build() error = %v, want an *UnsupportedFeatureError", err)
+			}
+			if unsupported.Feature != tt.feature {
+				t.Errorf("Feature = %q, want %q", unsupported.Feature, tt.feature)
+			}
+		})
+	}
+}
+
+func TestMySQLSQLFor(t *testing.T) {
+	dialecttest.WithDialect(t, tomasql.DefaultDialect)
+
+	query := tomasql.Select(Users.Id).From(Users).Where(Users.Name.EqParam("bob"))
+	if got, _ := query.SQLFor(GetDialect()); got != "SELECT `users`.`id` FROM `users` WHERE `users`.`name` = ?" {
+		t.Errorf("SQLFor() = %s", got)
+	}
+	if got, _ := query.SQL(); got != "SELECT users.id FROM users WHERE users.name = ?" {
+		t.Errorf("SQL() = %s, want the default dialect", got)
+	}
+}
+
+func TestMySQLSubqueryConditionParamOrder(t *testing.T) {
+	dialecttest.WithDialect(t, &MySQLDialect{})
+
+	sub := tomasql.Select(Users.Id).From(Users).Where(Users.Name.EqParam("bob")).AsSubQuery()
+	left := tomasql.Num[int64](Users.Id).AddParam(5)
+	tests := []struct {
+		name      string
+		condition tomasql.Condition
+		operator  string
+	}{
+		{"in", left.In(sub), "IN "},
+		{"not in", left.NotIn(sub), "NOT IN "},
+		{"any", left.EqAny(sub), "= ANY"},
+		{"all", left.GtAll(sub), "> ALL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSql, gotParams := tomasql.Select(Users.Id).From(Users).Where(tt.condition).SQL()
+			wantSql := "SELECT `users`.`id` FROM `users` WHERE `users`.`id` + ? " + tt.operator +
+				"(SELECT `users`.`id` FROM `users` WHERE `users`.`name` = ?)"
+			if gotSql != wantSql {
+				t.Errorf("SQL() =\n%s\nwant\n%s", gotSql, wantSql)
+			}
+			if want := []any{int64(5), "bob"}; !reflect.DeepEqual(gotParams, want) {
+				t.Errorf("params = %v, want %v", gotParams, want)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package pgres
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/sergiobonfiglio/tomasql"
 )
 
-type PostgresDialect struct{}
+type PostgresDialect struct {
+	tomasql.StandardDialect
+}
 
 var _ tomasql.Dialect = (*PostgresDialect)(nil)
 
@@ -18,3 +21,39 @@ func (p *PostgresDialect) Name() string {
 func (p *PostgresDialect) Placeholder(position int) string {
 	return fmt.Sprintf("$%d", position)
 }
+
+// NumberedPlaceholders implements tomasql.Dialect.
+func (p *PostgresDialect) NumberedPlaceholders() bool {
+	return true
+}
+
+// Supports implements tomasql.Dialect.
+func (p *PostgresDialect) Supports(feature tomasql.Feature) bool {
+	switch feature {
+	case tomasql.FeatureReturning, tomasql.FeatureOnConflict, tomasql.FeatureFilter,
+		tomasql.FeatureRightJoin, tomasql.FeatureFullJoin, tomasql.FeatureLateral, tomasql.FeatureJoinUsing,
+		tomasql.FeatureUpdateFrom, tomasql.FeatureDeleteUsing,
+		tomasql.FeatureGroupingSets, tomasql.FeatureDistinctOn, tomasql.FeatureUnorderedLimit,
+		tomasql.FeatureDerivedColumnList, tomasql.FeatureParenthesizedSetOperand, tomasql.FeatureNestedWith,
+		tomasql.FeatureRecursiveCTE, tomasql.FeatureStringAgg, tomasql.FeatureArrayAgg, tomasql.FeatureAggregateOrderBy,
+		tomasql.FeatureWithinGroup, tomasql.FeatureILike,
+		tomasql.FeatureForUpdate, tomasql.FeatureForNoKeyUpdate, tomasql.FeatureForShare,
+		tomasql.FeatureSkipLocked, tomasql.FeatureNoWait:
+		return true
+	default:
+		return false
+	}
+}
+
+// CastType implements tomasql.Dialect. Types not listed here use the standard names.
+func (p *PostgresDialect) CastType(t reflect.Type) (string, bool) {
+	switch t.Kind() {
+	case reflect.String:
+		return "TEXT", true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BYTEA", true
+		}
+	}
+	return p.StandardDialect.CastType(t)
+}
@@ -1,9 +1,11 @@
 package pgres
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/internal/dialecttest"
 )
 
 func TestPostgresDialectName(t *testing.T) {
@@ -93,3 +95,92 @@ func TestPostgresDialectMultiplePlaceholders(t *testing.T) {
 		}
 	}
 }
+
+func TestPostgresDialectSupports(t *testing.T) {
+	dialect := &PostgresDialect{}
+
+	for _, feature := range []tomasql.Feature{
+		tomasql.FeatureReturning,
+		tomasql.FeatureForUpdate,
+		tomasql.FeatureForNoKeyUpdate,
+		tomasql.FeatureSkipLocked,
+		tomasql.FeatureGroupingSets,
+		tomasql.FeatureDistinctOn,
+		tomasql.FeatureNestedWith,
+		tomasql.FeatureRecursiveCTE,
+		tomasql.FeatureArrayAgg,
+		tomasql.FeatureWithinGroup,
+		tomasql.FeatureILike,
+		tomasql.FeatureJoinUsing,
+		tomasql.FeatureUpdateFrom,
+		tomasql.FeatureDeleteUsing,
+	} {
+		if !dialect.Supports(feature) {
+			t.Errorf("Supports(%q) = false, want true", feature)
+		}
+	}
+}
+
+func TestPostgresDialectCastType(t *testing.T) {
+	dialect := &PostgresDialect{}
+	tests := []struct {
+		typ    reflect.Type
+		want   string
+		wantOk bool
+	}{
+		{reflect.TypeFor[string](), "TEXT", true},
+		{reflect.TypeFor[[]byte](), "BYTEA", true},
+		{reflect.TypeFor[int](), "INTEGER", true},
+		{reflect.TypeFor[map[string]int](), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			got, ok := dialect.CastType(tt.typ)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CastType(%s) = %q, %v, want %q, %v", tt.typ, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestPostgresCast(t *testing.T) {
+	originalDialect := tomasql.GetDialect()
+	defer tomasql.SetDialect(originalDialect)
+	SetDialect()
+
+	got, _ := tomasql.Cast[string](tomasql.NewCol[int]("id", nil)).SqlWithParams(&tomasql.ParamsMap{}, tomasql.ReferenceContext)
+	if want := "CAST(id AS TEXT)"; got != want {
+		t.Errorf("Cast[string] = %q, want %q", got, want)
+	}
+
+	got, _ = tomasql.Cast[int](tomasql.NewCol[string]("uuid", nil)).SqlWithParams(&tomasql.ParamsMap{}, tomasql.ReferenceContext)
+	if want := "CAST(uuid AS INTEGER)"; got != want {
+		t.Errorf("Cast[int] = %q, want %q", got, want)
+	}
+}
+
+func TestPostgresUnhashableParams(t *testing.T) {
+	dialecttest.WithDialect(t, &PostgresDialect{})
+	users := dialecttest.Users
+	avatar := tomasql.NewCol[[]byte]("avatar", users)
+
+	sql, params := tomasql.InsertInto(users).Columns(avatar).Values(avatar.Value([]byte{1, 2})).SQL()
+	if want := "INSERT INTO users (avatar) VALUES ($1)"; sql != want {
+		t.Errorf("SQL() = %q, want %q", sql, want)
+	}
+	if want := []any{[]byte{1, 2}}; !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+
+	// unhashable values are not shared, even when equal
+	sql, params = tomasql.Select(users.Id).From(users).
+		Where(avatar.EqParam([]byte{1}).Or(avatar.EqParam([]byte{1}))).
+		SQL()
+	if want := "SELECT users.id FROM users WHERE users.avatar = $1 OR users.avatar = $2"; sql != want {
+		t.Errorf("SQL() = %q, want %q", sql, want)
+	}
+	if want := []any{[]byte{1}, []byte{1}}; !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+}
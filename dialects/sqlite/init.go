@@ -0,0 +1,22 @@
+package sqlite
+
+import "github.com/sergiobonfiglio/tomasql"
+
+var sqliteDialect *SQLiteDialect = &SQLiteDialect{}
+
+func GetDialect() *SQLiteDialect {
+	return sqliteDialect
+}
+
+// SetDialect sets the SQLite dialect for the latest SQLite version as the current tomasql dialect.
+// Equivalent to calling tomasql.SetDialect(&SQLiteDialect{}).
+func SetDialect() {
+	tomasql.SetDialect(&SQLiteDialect{})
+}
+
+// SetDialectVersion sets the SQLite dialect for the given SQLite version (e.g. "3.31.1") as the current tomasql
+// dialect, so that the constructs that version does not support are rejected.
+// Equivalent to calling tomasql.SetDialect(&SQLiteDialect{Version: version}).
+func SetDialectVersion(version string) {
+	tomasql.SetDialect(&SQLiteDialect{Version: version})
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sergiobonfiglio/tomasql"
+)
+
+// SQLiteDialect renders queries for SQLite.
+type SQLiteDialect struct {
+	tomasql.StandardDialect
+	// Version is the SQLite version queries are rendered for, e.g. "3.38.5". The features added after it are not
+	// supported. An empty Version stands for the latest SQLite version.
+	Version string
+}
+
+var _ tomasql.Dialect = (*SQLiteDialect)(nil)
+
+// featureVersions are the SQLite versions that introduced the supported features, empty for the features of every
+// version.
+var featureVersions = map[tomasql.Feature]string{
+	tomasql.FeatureUnorderedLimit:   "",
+	tomasql.FeatureJoinUsing:        "",
+	tomasql.FeatureNestedWith:       "3.8.3",
+	tomasql.FeatureRecursiveCTE:     "3.8.3",
+	tomasql.FeatureOnConflict:       "3.24.0",
+	tomasql.FeatureFilter:           "3.30.0",
+	tomasql.FeatureUpdateFrom:       "3.33.0",
+	tomasql.FeatureReturning:        "3.35.0",
+	tomasql.FeatureRightJoin:        "3.39.0",
+	tomasql.FeatureFullJoin:         "3.39.0",
+	tomasql.FeatureStringAgg:        "3.44.0",
+	tomasql.FeatureAggregateOrderBy: "3.44.0",
+}
+
+func (s *SQLiteDialect) Name() string {
+	if s.Version == "" {
+		return "sqlite"
+	}
+	return "sqlite " + s.Version
+}
+
+// Placeholder implements tomasql.Dialect with ?NNN placeholders, so that a parameter used twice is passed once.
+func (s *SQLiteDialect) Placeholder(position int) string {
+	return fmt.Sprintf("?%d", position)
+}
+
+// NumberedPlaceholders implements tomasql.Dialect.
+func (s *SQLiteDialect) NumberedPlaceholders() bool {
+	return true
+}
+
+// Supports implements tomasql.Dialect.
+func (s *SQLiteDialect) Supports(feature tomasql.Feature) bool {
+	since, ok := featureVersions[feature]
+	return ok && s.atLeast(since)
+}
+
+// LimitOffset implements tomasql.Dialect: SQLite needs a LIMIT before OFFSET, where -1 means no limit.
+func (s *SQLiteDialect) LimitOffset(limit, offset *int) string {
+	switch {
+	case offset == nil:
+		return fmt.Sprintf("LIMIT %d", *limit)
+	case limit == nil:
+		return fmt.Sprintf("LIMIT -1 OFFSET %d", *offset)
+	default:
+		return fmt.Sprintf("LIMIT %d OFFSET %d", *limit, *offset)
+	}
+}
+
+// BoolLiteral implements tomasql.Dialect. TRUE and FALSE are only keywords since SQLite 3.23.0.
+func (s *SQLiteDialect) BoolLiteral(value bool) string {
+	switch {
+	case s.atLeast("3.23.0") && value:
+		return "TRUE"
+	case s.atLeast("3.23.0"):
+		return "FALSE"
+	case value:
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+// RenderOperator implements tomasql.Dialect. IS and IS NOT are the null-safe comparisons of every SQLite version,
+// while IS [NOT] DISTINCT FROM was only added in 3.39.0.
+func (s *SQLiteDialect) RenderOperator(op tomasql.Operator, operands []string) string {
+	switch op {
+	case tomasql.OperatorNotDistinctFrom:
+		return operands[0] + " IS " + operands[1]
+	case tomasql.OperatorDistinctFrom:
+		return operands[0] + " IS NOT " + operands[1]
+	default:
+		return s.StandardDialect.RenderOperator(op, operands)
+	}
+}
+
+// CastType implements tomasql.Dialect with the names of the SQLite storage classes.
+func (s *SQLiteDialect) CastType(t reflect.Type) (string, bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Bool:
+		return "INTEGER", true
+	case reflect.Float32, reflect.Float64:
+		return "REAL", true
+	case reflect.String:
+		return "TEXT", true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB", true
+		}
+	}
+	return "", false
+}
+
+// atLeast reports whether the dialect version is version or a later one.
+func (s *SQLiteDialect) atLeast(version string) bool {
+	if s.Version == "" {
+		return true
+	}
+	have, want := parseVersion(s.Version), parseVersion(version)
+	for i := range have {
+		if have[i] != want[i] {
+			return have[i] > want[i]
+		}
+	}
+	return true
+}
+
+// parseVersion parses a major.minor.patch version, where missing or invalid parts count as 0.
+func parseVersion(version string) [3]int {
+	var parsed [3]int
+	for i, part := range strings.SplitN(version, ".", 3) {
+		parsed[i], _ = strconv.Atoi(part)
+	}
+	return parsed
+}
@@ -0,0 +1,306 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/internal/dialecttest"
+)
+
+var Users = dialecttest.Users
+
+func TestSQLiteDialectName(t *testing.T) {
+	tests := map[string]string{
+		"":       "sqlite",
+		"3.31.1": "sqlite 3.31.1",
+	}
+
+	for version, want := range tests {
+		dialect := &SQLiteDialect{Version: version}
+		if got := dialect.Name(); got != want {
+			t.Errorf("Name() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSQLiteDialectPlaceholder(t *testing.T) {
+	dialect := &SQLiteDialect{}
+	tests := map[int]string{
+		1:   "?1",
+		2:   "?2",
+		100: "?100",
+	}
+
+	for position, want := range tests {
+		if got := dialect.Placeholder(position); got != want {
+			t.Errorf("Placeholder(%d) = %q, want %q", position, got, want)
+		}
+	}
+}
+
+func TestGetDialect(t *testing.T) {
+	dialect := GetDialect()
+	if dialect == nil {
+		t.Fatal("GetDialect() returned nil")
+	}
+	if dialect.Name() != "sqlite" {
+		t.Errorf("GetDialect().Name() = %q, want %q", dialect.Name(), "sqlite")
+	}
+}
+
+func TestSetDialect(t *testing.T) {
+	dialecttest.WithDialect(t, tomasql.DefaultDialect)
+	SetDialect()
+
+	if got := tomasql.GetDialect().Name(); got != "sqlite" {
+		t.Errorf("After SetDialect(), dialect name = %q, want %q", got, "sqlite")
+	}
+}
+
+func TestSQLiteDialectSupports(t *testing.T) {
+	tests := []struct {
+		version string
+		feature tomasql.Feature
+		want    bool
+	}{
+		{"", tomasql.FeatureReturning, true},
+		{"", tomasql.FeatureOnConflict, true},
+		{"", tomasql.FeatureFilter, true},
+		{"", tomasql.FeatureRightJoin, true},
+		{"", tomasql.FeatureFullJoin, true},
+		{"", tomasql.FeatureLateral, false},
+		{"", tomasql.FeatureForUpdate, false},
+		{"", tomasql.FeatureOnDuplicateKey, false},
+		{"", tomasql.FeatureDerivedColumnList, false},
+		{"", tomasql.FeatureParenthesizedSetOperand, false},
+		{"3.35.0", tomasql.FeatureReturning, true},
+		{"3.34.1", tomasql.FeatureReturning, false},
+		{"3.38.5", tomasql.FeatureRightJoin, false},
+		{"3.38.5", tomasql.FeatureFullJoin, false},
+		{"3.39", tomasql.FeatureFullJoin, true},
+		{"3.22.0", tomasql.FeatureOnConflict, false},
+		{"3.30.1", tomasql.FeatureFilter, true},
+		{"", tomasql.FeatureRecursiveCTE, true},
+		{"", tomasql.FeatureNestedWith, true},
+		{"3.43.2", tomasql.FeatureStringAgg, false},
+		{"3.44.0", tomasql.FeatureAggregateOrderBy, true},
+		{"", tomasql.FeatureArrayAgg, false},
+		{"", tomasql.FeatureWithinGroup, false},
+		{"", tomasql.FeatureJoinUsing, true},
+		{"", tomasql.FeatureDeleteUsing, false},
+		{"3.33.0", tomasql.FeatureUpdateFrom, true},
+		{"3.32.3", tomasql.FeatureUpdateFrom, false},
+	}
+
+	for _, tt := range tests {
+		dialect := &SQLiteDialect{Version: tt.version}
+		if got := dialect.Supports(tt.feature); got != tt.want {
+			t.Errorf("version %q: Supports(%q) = %v, want %v", tt.version, tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteDialectLimitOffset(t *testing.T) {
+	dialect := &SQLiteDialect{}
+	ten, twenty := 10, 20
+	tests := []struct {
+		limit, offset *int
+		want          string
+	}{
+		{&ten, nil, "LIMIT 10"},
+		{&ten, &twenty, "LIMIT 10 OFFSET 20"},
+		{nil, &twenty, "LIMIT -1 OFFSET 20"},
+	}
+
+	for _, tt := range tests {
+		if got := dialect.LimitOffset(tt.limit, tt.offset); got != tt.want {
+			t.Errorf("LimitOffset() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteDialectBoolLiteral(t *testing.T) {
+	tests := []struct {
+		version string
+		value   bool
+		want    string
+	}{
+		{"", true, "TRUE"},
+		{"", false, "FALSE"},
+		{"3.22.0", true, "1"},
+		{"3.22.0", false, "0"},
+	}
+
+	for _, tt := range tests {
+		dialect := &SQLiteDialect{Version: tt.version}
+		if got := dialect.BoolLiteral(tt.value); got != tt.want {
+			t.Errorf("version %q: BoolLiteral(%v) = %q, want %q", tt.version, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteDialectCastType(t *testing.T) {
+	dialect := &SQLiteDialect{}
+	tests := []struct {
+		typ    reflect.Type
+		want   string
+		wantOk bool
+	}{
+		{reflect.TypeFor[int](), "INTEGER", true},
+		{reflect.TypeFor[int64](), "INTEGER", true},
+		{reflect.TypeFor[bool](), "INTEGER", true},
+		{reflect.TypeFor[float64](), "REAL", true},
+		{reflect.TypeFor[string](), "TEXT", true},
+		{reflect.TypeFor[[]byte](), "BLOB", true},
+		{reflect.TypeFor[time.Time](), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			got, ok := dialect.CastType(tt.typ)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CastType(%s) = %q, %v, want %q, %v", tt.typ, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSQLiteQueries(t *testing.T) {
+	dialecttest.WithDialect(t, &SQLiteDialect{Version: ""})
+
+	tests := []struct {
+		name       string
+		query      tomasql.SQLable
+		wantSql    string
+		wantParams []any
+	}{
+		{
+			name: "select with reused param and limit",
+			query: tomasql.Select(Users.Id).From(Users).
+				Where(Users.Name.EqParam("bob").Or(Users.Name.LikeParam("bob"))).
+				OrderBy(Users.Id.Asc()).
+				Limit(10).
+				Offset(20),
+			wantSql: "SELECT users.id FROM users WHERE users.name = ?1 OR users.name LIKE ?1 " +
+				"ORDER BY users.id ASC LIMIT 10 OFFSET 20",
+			wantParams: []any{"bob"},
+		},
+		{
+			name: "null-safe comparisons",
+			query: tomasql.Select(Users.Id).From(Users).
+				Where(Users.Name.IsNotDistinctFromParam("bob").Or(Users.Id.IsDistinctFrom(tomasql.Param(int64(1))))),
+			wantSql:    "SELECT users.id FROM users WHERE users.name IS ?1 OR users.id IS NOT ?2",
+			wantParams: []any{"bob", int64(1)},
+		},
+		{
+			name:    "full join",
+			query:   tomasql.SelectAll().From(Users).FullJoin(Users.As("u2")).On(Users.As("u2").Id.Eq(Users.Id)),
+			wantSql: "SELECT * FROM users FULL JOIN users AS u2 ON u2.id = users.id",
+		},
+		{
+			name: "filtered aggregate",
+			query: tomasql.Select(tomasql.Count().Filter(Users.Active.Eq(tomasql.Literal(true)))).
+				From(Users),
+			wantSql: "SELECT COUNT(1) FILTER (WHERE users.active = TRUE) FROM users",
+		},
+		{
+			name: "upsert returning",
+			query: tomasql.InsertInto(Users).
+				Columns(Users.Id, Users.Name).
+				Values(Users.Id.Value(1), Users.Name.Value("bob")).
+				OnConflict(Users.Id).
+				DoUpdateSet(Users.Name.Set(Users.Name.Excluded())).
+				Returning(Users.Id),
+			wantSql: "INSERT INTO users (id, name) VALUES (?1, ?2) " +
+				"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name RETURNING users.id",
+			wantParams: []any{int64(1), "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSql, gotParams := tt.query.SQL()
+			if gotSql != tt.wantSql {
+				t.Errorf("SQL() =\n%s\nwant\n%s", gotSql, tt.wantSql)
+			}
+			if len(gotParams) != len(tt.wantParams) || (len(gotParams) > 0 && !reflect.DeepEqual(gotParams, tt.wantParams)) {
+				t.Errorf("params = %v, want %v", gotParams, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestSQLiteOlderVersions(t *testing.T) {
+	tests := []struct {
+		version string
+		feature tomasql.Feature
+		query   func() tomasql.SQLable
+	}{
+		{"3.34.1", tomasql.FeatureReturning, func() tomasql.SQLable {
+			return tomasql.DeleteFrom(Users).Where(Users.Id.EqParam(1)).Returning(Users.Id)
+		}},
+		{"3.38.5", tomasql.FeatureRightJoin, func() tomasql.SQLable {
+			return tomasql.SelectAll().From(Users).RightJoin(Users.As("u2")).On(Users.As("u2").Id.Eq(Users.Id))
+		}},
+		{"3.32.3", tomasql.FeatureUpdateFrom, func() tomasql.SQLable {
+			return tomasql.Update(Users).Set(Users.Name.Set(Users.As("u2").Name)).From(Users.As("u2")).
+				Where(Users.As("u2").Id.Eq(Users.Id))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			dialecttest.WithDialect(t, &SQLiteDialect{Version: tt.version})
+
+			defer func() {
+				err, ok := recover().(*tomasql.UnsupportedFeatureError)
+				if !ok {
+					t.Fatalf("expected an *UnsupportedFeatureError panic, got %v", err)
+				}
+				if err.Feature != tt.feature {
+					t.Errorf("Feature = %q, want %q", err.Feature, tt.feature)
+				}
+			}()
+			tt.query().SQL()
+		})
+	}
+}
+
+func TestSQLiteUnsupportedFeatures(t *testing.T) {
+	tests := []struct {
+		name    string
+		feature tomasql.Feature
+		query   func() tomasql.SQLable
+	}{
+		{"values list", tomasql.FeatureDerivedColumnList, func() tomasql.SQLable {
+			values, _ := tomasql.Values(tomasql.Row(tomasql.Param(1))).As("v", Users.Id)
+			return tomasql.SelectAll().From(values)
+		}},
+		{"limited set operation operand", tomasql.FeatureParenthesizedSetOperand, func() tomasql.SQLable {
+			return tomasql.Select(Users.Id).From(Users).OrderBy(Users.Id.Asc()).Limit(1).
+				UnionAll(tomasql.Select(Users.Id).From(Users))
+		}},
+		{"delete using", tomasql.FeatureDeleteUsing, func() tomasql.SQLable {
+			return tomasql.DeleteFrom(Users).Using(Users.As("u2")).Where(Users.As("u2").Id.Eq(Users.Id))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialecttest.WithDialect(t, &SQLiteDialect{Version: ""})
+
+			defer func() {
+				err, ok := recover().(*tomasql.UnsupportedFeatureError)
+				if !ok {
+					t.Fatalf("expected an *UnsupportedFeatureError panic, got %v", err)
+				}
+				if err.Feature != tt.feature {
+					t.Errorf("Feature = %q, want %q", err.Feature, tt.feature)
+				}
+			}()
+			tt.query().SQL()
+		})
+	}
+}
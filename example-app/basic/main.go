@@ -74,4 +74,18 @@ func Run() {
 	fmt.Printf("SQL: %s\n", sql)
 	fmt.Printf("Params: %v\n", params)
 
+	// Example 7: Recursive CTE walking the category tree
+	fmt.Println("\n--- Example 7: Recursive CTE ---")
+	tree := tomasql.WithRecursive("category_tree", tomasql.Select(Categories.Id, Categories.Name).
+		From(Categories).
+		Where(Categories.ParentId.IsNull()))
+	tree.UnionAll(tomasql.Select(Categories.Id, Categories.Name).
+		From(Categories).
+		Join(tree).On(Categories.ParentId.Eq(tomasql.DerivedCol(tree, Categories.Id))))
+	sql, params = tomasql.Select(tomasql.DerivedCol(tree, Categories.Name)).
+		From(tree).
+		SQL()
+	fmt.Printf("SQL: %s\n", sql)
+	fmt.Printf("Params: %v\n", params)
+
 }
@@ -9,8 +9,7 @@ import (
 )
 
 type sqlableArray struct {
-	array  sql.Scanner
-	params tomasql.ParamsMap
+	array sql.Scanner
 }
 
 var _ tomasql.ParametricSql = &sqlableArray{}
@@ -20,17 +19,11 @@ func Array[T any](array []T) tomasql.ParametricSql {
 }
 
 func newSQLableArray(array any) tomasql.ParametricSql {
-	return &sqlableArray{array: pq.Array(array), params: tomasql.ParamsMap{}}
+	return &sqlableArray{array: pq.Array(array)}
 }
 
-func (s *sqlableArray) SqlWithParams(params tomasql.ParamsMap, _ tomasql.RenderContext) (string, tomasql.ParamsMap) {
-	s.params = params.AddAll(s.params)
-
+func (s *sqlableArray) SqlWithParams(params *tomasql.ParamsMap, _ tomasql.RenderContext) (string, *tomasql.ParamsMap) {
 	// Use a pointer to the slice as a key to ensure it's hashable. Note: we'll have to pass the array
 	// multiple times in the query params even if it's the same array, but it should be fine for most use cases.
-	arrayKey := &s.array
-	if _, ok := s.params[arrayKey]; !ok {
-		s.params[arrayKey] = len(s.params) + 1
-	}
-	return fmt.Sprintf("(%s)", tomasql.GetDialect().Placeholder(s.params[arrayKey])), s.params
+	return fmt.Sprintf("(%s)", params.Placeholder(&s.array)), params
 }
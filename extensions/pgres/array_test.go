@@ -11,7 +11,8 @@ import (
 //go:generate go run ../../cmd/table-def-gen --schema ../../cmd/table-def-gen/example_schema.sql --package-dir ../pgres --table-def-file tables-definitions_gen_test.go --table-graph-file= --with-pgres-extensions
 
 func TestArray(t *testing.T) {
-
+	originalDialect := tomasql.GetDialect()
+	t.Cleanup(func() { tomasql.SetDialect(originalDialect) })
 	pgres.SetDialect()
 
 	type test struct {
@@ -22,27 +23,27 @@ func TestArray(t *testing.T) {
 	tests := []test{
 		{
 			want: "account.id IN ($1)",
-			got:  Account.Id.InArray([]int64{1}).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.InArray([]int64{1}).SQL(&tomasql.ParamsMap{}),
 		},
 		{
 			want: "account.id IN ($1, $1, $2, $3, $1)",
-			got:  Account.Id.InArray([]int64{1, 1, 2, 3, 1}).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.InArray([]int64{1, 1, 2, 3, 1}).SQL(&tomasql.ParamsMap{}),
 		},
 		{
 			want: "account.id = ANY($1)",
-			got:  Account.Id.EqAny(Array([]int64{1})).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.EqAny(Array([]int64{1})).SQL(&tomasql.ParamsMap{}),
 		},
 		{
 			want: "account.id > ANY($1)",
-			got:  Account.Id.GtAny(Array([]int64{1, 1, 2, 3, 1})).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.GtAny(Array([]int64{1, 1, 2, 3, 1})).SQL(&tomasql.ParamsMap{}),
 		},
 		{
 			want: "account.id = ALL($1)",
-			got:  Account.Id.EqAll(Array([]int64{1})).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.EqAll(Array([]int64{1})).SQL(&tomasql.ParamsMap{}),
 		},
 		{
 			want: "account.id > ALL($1)",
-			got:  Account.Id.GtAll(Array([]int64{1, 1, 2, 3, 1})).SQL(tomasql.ParamsMap{}),
+			got:  Account.Id.GtAll(Array([]int64{1, 1, 2, 3, 1})).SQL(&tomasql.ParamsMap{}),
 		},
 	}
 
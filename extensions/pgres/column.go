@@ -18,11 +18,11 @@ func Wrap[T any](c *tomasql.Col[T]) *PGCol[T] {
 const comparerILike = "ILIKE" // case-insensitive LIKE
 
 func (c PGCol[T]) ILike(other tomasql.ParametricSql) tomasql.Condition {
-	return tomasql.NewBinaryCondition(c, other, comparerILike)
+	return newILikeCondition(tomasql.NewBinaryCondition(c, other, comparerILike))
 }
 
 func (c PGCol[T]) ILikeParam(other string) tomasql.Condition {
-	return tomasql.NewBinaryParamCondition(c, other, comparerILike)
+	return newILikeCondition(tomasql.NewBinaryParamCondition(c, other, comparerILike))
 }
 
 // func (f *funcCol[T]) ILike(other ParametricSql) Condition {
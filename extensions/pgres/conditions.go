@@ -26,20 +26,16 @@ func newInArrayCondition[T any](col ParametricSql, array []T) *InArrayCondition[
 	return &InArrayCondition[T]{col: col, array: array}
 }
 
-func (i *InArrayCondition[T]) SQL(params ParamsMap) string {
+func (i *InArrayCondition[T]) SQL(params *ParamsMap) string {
+	// Render the left side first, so that positional placeholders take their parameters in order
+	colSql, _ := i.col.SqlWithParams(params, ReferenceContext)
+
 	paramsStr := make([]string, len(i.array))
 	for ix, pItem := range i.array {
-		// If the parameter is not already in the map, add it
-		if _, ok := params[pItem]; !ok {
-			params[pItem] = len(params) + 1
-		}
-		order := params[pItem]
-		paramsStr[ix] = GetDialect().Placeholder(order)
+		paramsStr[ix] = params.Placeholder(pItem)
 	}
 	allParams := strings.Join(paramsStr, ", ")
 
-	colSql, _ := i.col.SqlWithParams(params, ReferenceContext)
-
 	return fmt.Sprintf("%s IN (%s)", colSql, allParams)
 }
 
@@ -50,3 +46,34 @@ func (i *InArrayCondition[T]) And(condition Condition) Condition {
 func (i *InArrayCondition[T]) Or(condition Condition) Condition {
 	return NewConcatCondition(OrCondConnector, i, condition)
 }
+
+// ILikeCondition is a case-insensitive ILIKE comparison, which is only rendered by the dialects supporting
+// tomasql.FeatureILike.
+type ILikeCondition struct {
+	comparison Condition
+}
+
+var _ Condition = &ILikeCondition{}
+
+func newILikeCondition(comparison Condition) *ILikeCondition {
+	return &ILikeCondition{comparison: comparison}
+}
+
+func (i *ILikeCondition) Columns() []Column {
+	return i.comparison.Columns()
+}
+
+func (i *ILikeCondition) SQL(params *ParamsMap) string {
+	if dialect := params.Dialect(); !dialect.Supports(FeatureILike) {
+		panic(&UnsupportedFeatureError{Dialect: dialect.Name(), Feature: FeatureILike})
+	}
+	return i.comparison.SQL(params)
+}
+
+func (i *ILikeCondition) And(condition Condition) Condition {
+	return NewConcatCondition(AndCondConnector, i, condition)
+}
+
+func (i *ILikeCondition) Or(condition Condition) Condition {
+	return NewConcatCondition(OrCondConnector, i, condition)
+}
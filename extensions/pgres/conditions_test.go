@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/pgres"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,6 +16,11 @@ func TestCondition_Columns(t *testing.T) {
 	}
 
 	tests := []test{
+		{
+			name: "ilike condition columns",
+			impl: Account.Uuid.ILikeParam("a%"),
+			want: []tomasql.Column{*Account.Uuid},
+		},
 		{
 			name: "in array condition columns",
 			impl: newInArrayCondition(tomasql.NewCol[int64]("col1", nil), []int64{1, 2, 3}),
@@ -24,9 +30,38 @@ func TestCondition_Columns(t *testing.T) {
 
 	for _, testItem := range tests {
 		got := testItem.impl.Columns()
-		name := testItem.impl.SQL(tomasql.ParamsMap{})
+		name := testItem.impl.SQL(tomasql.NewParamsMap(pgres.GetDialect()))
 		t.Run(testItem.name+"_"+name, func(tt *testing.T) {
 			require.ElementsMatch(tt, testItem.want, got)
 		})
 	}
 }
+
+func TestILike(t *testing.T) {
+	t.Run("pgres dialect", func(t *testing.T) {
+		sql, params := tomasql.Select(Account.Id).From(Account).
+			Where(Account.Uuid.ILikeParam("a%").Or(Account.Type.ILike(Account.Uuid))).
+			SQLFor(pgres.GetDialect())
+
+		require.Equal(t, "SELECT account.id FROM account WHERE account.uuid ILIKE $1 OR account.type ILIKE account.uuid", sql)
+		require.Equal(t, []any{"a%"}, params)
+	})
+
+	t.Run("rejected by other dialects", func(t *testing.T) {
+		query := tomasql.Select(Account.Id).From(Account).Where(Account.Id.EqParam(1).And(Account.Uuid.ILikeParam("a%")))
+
+		_, _, err := query.RenderWith(tomasql.DefaultDialect).Build()
+		require.EqualError(t, err, "tomasql: ILIKE is not supported by the standard dialect")
+	})
+}
+
+func TestInArrayParamOrder(t *testing.T) {
+	// with positional placeholders, the parameters of the left side come first
+	left := tomasql.Concat(Account.Uuid, tomasql.Param("-x"))
+	sql, params := tomasql.Select(Account.Id).From(Account).
+		Where(newInArrayCondition(left, []string{"a-x", "b-x"})).
+		SQLFor(tomasql.DefaultDialect)
+
+	require.Equal(t, "SELECT account.id FROM account WHERE account.uuid || ? IN (?, ?)", sql)
+	require.Equal(t, []any{"-x", "a-x", "b-x"}, params)
+}
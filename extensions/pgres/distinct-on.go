@@ -0,0 +1,85 @@
+package pgres
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sergiobonfiglio/tomasql"
+)
+
+// DistinctOnBuilder is the first step of a SELECT DISTINCT ON query, see SelectDistinctOn.
+type DistinctOnBuilder struct {
+	distinctOn *distinctOn
+}
+
+// SelectDistinctOn starts a SELECT DISTINCT ON (onExprs) query, which keeps the first row of each group of rows with
+// the same onExprs. The ORDER BY of the query, if any, must start with onExprs: it determines which row comes first.
+func SelectDistinctOn(onExpr tomasql.ParametricSql, onExprs ...tomasql.ParametricSql) *DistinctOnBuilder {
+	return &DistinctOnBuilder{distinctOn: &distinctOn{exprs: append([]tomasql.ParametricSql{onExpr}, onExprs...)}}
+}
+
+// Columns sets the select list of the query.
+func (d *DistinctOnBuilder) Columns(first tomasql.ParametricSql, columns ...tomasql.ParametricSql) tomasql.BuilderWithSelect {
+	return tomasql.NewSelectWithModifier(d.distinctOn, first, columns...)
+}
+
+type distinctOn struct {
+	exprs []tomasql.ParametricSql
+}
+
+var _ tomasql.SelectModifier = &distinctOn{}
+
+func (d *distinctOn) SqlWithParams(params *tomasql.ParamsMap, _ tomasql.RenderContext) (string, *tomasql.ParamsMap) {
+	if dialect := params.Dialect(); !dialect.Supports(tomasql.FeatureDistinctOn) {
+		panic(&tomasql.UnsupportedFeatureError{Dialect: dialect.Name(), Feature: tomasql.FeatureDistinctOn})
+	}
+	exprsSql := make([]string, len(d.exprs))
+	for i, expr := range d.exprs {
+		exprsSql[i], params = expr.SqlWithParams(params, tomasql.ReferenceContext)
+	}
+	return "DISTINCT ON (" + strings.Join(exprsSql, ", ") + ")", params
+}
+
+// ValidateOrderBy implements tomasql.SelectModifier. Postgres requires the leftmost ORDER BY expressions to be the
+// DISTINCT ON expressions, in any order; the ORDER BY can be shorter though.
+func (d *distinctOn) ValidateOrderBy(dialect tomasql.Dialect, sortExprs []tomasql.ParametricSql) error {
+	onKeys := make([]string, len(d.exprs))
+	for i, expr := range d.exprs {
+		onKeys[i] = exprKey(dialect, expr)
+	}
+
+	matched := map[string]bool{}
+	for i, sortExpr := range sortExprs {
+		if len(matched) == len(onKeys) {
+			return nil
+		}
+		key := exprKey(dialect, sortExpr)
+		if !slices.Contains(onKeys, key) {
+			return &DistinctOnOrderError{DistinctOn: onKeys, OrderBy: key, Position: i + 1}
+		}
+		matched[key] = true
+	}
+	return nil
+}
+
+// exprKey renders expr on its own in dialect d, so that the same expression always gets the same key.
+func exprKey(d tomasql.Dialect, expr tomasql.ParametricSql) string {
+	sql, _ := expr.SqlWithParams(tomasql.NewParamsMap(d), tomasql.ReferenceContext)
+	return sql
+}
+
+// DistinctOnOrderError is the panic value used when the ORDER BY of a SELECT DISTINCT ON query does not start with
+// the DISTINCT ON expressions.
+type DistinctOnOrderError struct {
+	DistinctOn []string
+	// OrderBy is the first ORDER BY expression that is not a DISTINCT ON expression
+	OrderBy string
+	// Position is the 1-based position of OrderBy in the ORDER BY clause
+	Position int
+}
+
+func (e *DistinctOnOrderError) Error() string {
+	return fmt.Sprintf("pgres: ORDER BY must start with the DISTINCT ON expressions (%s), found %s at position %d",
+		strings.Join(e.DistinctOn, ", "), e.OrderBy, e.Position)
+}
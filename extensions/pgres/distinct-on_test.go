@@ -0,0 +1,108 @@
+package pgres
+
+import (
+	"testing"
+
+	"github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/pgres"
+	"github.com/stretchr/testify/require"
+)
+
+// quotedDialect is the Postgres dialect with quoted identifiers.
+type quotedDialect struct {
+	pgres.PostgresDialect
+}
+
+func (d *quotedDialect) QuoteIdentifier(name string) string {
+	return `"` + name + `"`
+}
+
+func TestSelectDistinctOn(t *testing.T) {
+	originalDialect := tomasql.GetDialect()
+	t.Cleanup(func() { tomasql.SetDialect(originalDialect) })
+	pgres.SetDialect()
+
+	t.Run("latest config per account", func(t *testing.T) {
+		sql, params := SelectDistinctOn(Config.AccountId).
+			Columns(Config.AccountId, Config.Uuid).
+			From(Config).
+			Where(Config.ArchivedTs.IsNull().And(Config.CreatedTs.GtParam(10))).
+			OrderBy(Config.AccountId.Asc(), Config.CreatedTs.Desc()).
+			SQL()
+
+		require.Equal(t, "SELECT DISTINCT ON (config.account_id) config.account_id, config.uuid FROM config "+
+			"WHERE config.archived_ts IS NULL AND config.created_ts > $1 "+
+			"ORDER BY config.account_id ASC, config.created_ts DESC", sql)
+		require.Equal(t, []any{10}, params)
+	})
+
+	t.Run("multiple expressions in any order", func(t *testing.T) {
+		sql, _ := SelectDistinctOn(Config.AccountId, Config.Uuid).
+			Columns(Config.Id).
+			From(Config).
+			OrderBy(Config.Uuid.Asc(), Config.AccountId.Desc(), Config.Id.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT DISTINCT ON (config.account_id, config.uuid) config.id FROM config "+
+			"ORDER BY config.uuid ASC, config.account_id DESC, config.id ASC", sql)
+	})
+
+	t.Run("without order by", func(t *testing.T) {
+		sql, _ := SelectDistinctOn(tomasql.Lower(Account.Type)).Columns(Account.Id).From(Account).SQL()
+		require.Equal(t, "SELECT DISTINCT ON (LOWER(account.type)) account.id FROM account", sql)
+	})
+
+	t.Run("shorter order by", func(t *testing.T) {
+		sql, _ := SelectDistinctOn(Config.AccountId, Config.Uuid).
+			Columns(Config.Id).
+			From(Config).
+			OrderBy(Config.AccountId.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT DISTINCT ON (config.account_id, config.uuid) config.id FROM config "+
+			"ORDER BY config.account_id ASC", sql)
+	})
+
+	t.Run("order by prefix mismatch", func(t *testing.T) {
+		query := SelectDistinctOn(Config.AccountId, Config.Uuid).
+			Columns(Config.Id).
+			From(Config).
+			OrderBy(Config.AccountId.Asc(), Config.CreatedTs.Desc(), Config.Uuid.Asc())
+
+		require.PanicsWithError(t, "pgres: ORDER BY must start with the DISTINCT ON expressions "+
+			"(config.account_id, config.uuid), found config.created_ts at position 2", func() {
+			query.SQL()
+		})
+
+		_, _, err := query.Build()
+		var orderErr *DistinctOnOrderError
+		require.ErrorAs(t, err, &orderErr)
+		require.Equal(t, 2, orderErr.Position)
+	})
+
+	t.Run("order by checked in the dialect of the query", func(t *testing.T) {
+		query := SelectDistinctOn(Config.AccountId).
+			Columns(Config.Id).
+			From(Config).
+			OrderBy(Config.AccountId.Asc(), Config.Id.Asc())
+
+		sql, _ := query.SQLFor(&quotedDialect{})
+		require.Equal(t, `SELECT DISTINCT ON ("config"."account_id") "config"."id" FROM "config" `+
+			`ORDER BY "config"."account_id" ASC, "config"."id" ASC`, sql)
+
+		query = SelectDistinctOn(Config.AccountId).Columns(Config.Id).From(Config).OrderBy(Config.Id.Asc())
+		_, _, err := query.RenderWith(&quotedDialect{}).Build()
+		require.EqualError(t, err, `pgres: ORDER BY must start with the DISTINCT ON expressions `+
+			`("config"."account_id"), found "config"."id" at position 1`)
+	})
+
+	t.Run("rejected by other dialects", func(t *testing.T) {
+		tomasql.SetDialect(tomasql.DefaultDialect)
+		defer pgres.SetDialect()
+
+		query := SelectDistinctOn(Config.AccountId).Columns(Config.Id).From(Config)
+		require.PanicsWithError(t, "tomasql: DISTINCT ON is not supported by the standard dialect", func() {
+			query.SQL()
+		})
+	})
+}
@@ -5,40 +5,39 @@ import (
 	"strings"
 )
 
-func Count(col ...ParametricSql) *FuncCol[int] {
+func Count(col ...ParametricSql) *AggregateCol[int] {
 	if len(col) > 1 {
 		panic("Count() accepts at most 1 column")
 	}
 	if len(col) == 0 {
-		return newFuncCol[int]("COUNT", NewCol[int]("1", nil))
+		return newAggregateCol[int]("COUNT", NewCol[int]("1", nil))
 	}
-	return newFuncCol[int]("COUNT", col[0])
+	return newAggregateCol[int]("COUNT", col[0])
 }
 
-func CountDistinct(col ParametricSql, otherCols ...ParametricSql) *FuncCol[int] {
+func CountDistinct(col ParametricSql, otherCols ...ParametricSql) *AggregateCol[int] {
 	allCols := append([]ParametricSql{col}, otherCols...)
-	colsPart := newMultiParametricSql(", ", allCols...)
-	return newFuncCol[int]("COUNT", newMultiParametricSql("", []ParametricSql{NewFixedCol("DISTINCT ", nil), colsPart}...))
+	return newAggregateCol[int]("COUNT", &distinctArgs{cols: allCols})
 }
 
 func Exists(subQuery ParametricSql) *FuncCol[bool] {
 	return newFuncCol[bool]("EXISTS", subQuery)
 }
 
-func Sum[T any](col ParametricSql) *FuncCol[T] {
-	return newFuncCol[T]("SUM", col)
+func Sum[T any](col ParametricSql) *AggregateCol[T] {
+	return newAggregateCol[T]("SUM", col)
 }
 
-func Avg[T any](col ParametricSql) *FuncCol[T] {
-	return newFuncCol[T]("AVG", col)
+func Avg[T any](col ParametricSql) *AggregateCol[T] {
+	return newAggregateCol[T]("AVG", col)
 }
 
-func Min[T any](col ParametricSql) *FuncCol[T] {
-	return newFuncCol[T]("MIN", col)
+func Min[T any](col ParametricSql) *AggregateCol[T] {
+	return newAggregateCol[T]("MIN", col)
 }
 
-func Max[T any](col ParametricSql) *FuncCol[T] {
-	return newFuncCol[T]("MAX", col)
+func Max[T any](col ParametricSql) *AggregateCol[T] {
+	return newAggregateCol[T]("MAX", col)
 }
 
 func Upper(col ParametricSql) *FuncCol[string] {
@@ -75,7 +74,7 @@ type MultiParametricSql struct {
 	sqlables  []ParametricSql
 }
 
-func (m *MultiParametricSql) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (m *MultiParametricSql) SqlWithParams(paramsMap *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	switch ctx {
 	case DefinitionContext:
 		var sqls []string
@@ -130,10 +129,20 @@ type FuncCol[T any] struct {
 	alias    *string // alias for the function column
 	funcName string  // name of the function, e.g. "COUNT", "SUM", etc.
 	inner    ParametricSql
+	over     *WindowDef // window of the function, see AggregateCol.Over and WindowFunc.Over
+	filter   Condition  // FILTER (WHERE ...) of the function, see AggregateCol.Filter
 	ComparableParam[T]
 }
 
-var _ FuncColumn = &FuncCol[any]{}
+var (
+	_ FuncColumn         = &FuncCol[any]{}
+	_ Expression[string] = &FuncCol[string]{}
+)
+
+func (f *FuncCol[T]) valueType() T {
+	var zero T
+	return zero
+}
 
 func (f *FuncCol[T]) As(s string) FuncColumn {
 	f.alias = &s
@@ -207,6 +216,38 @@ func (f *FuncCol[T]) LikeParam(pattern string) Condition {
 	return NewBinaryParamCondition(f, pattern, comparerLike)
 }
 
+func (f *FuncCol[T]) NotLike(other ParametricSql) Condition {
+	return NewBinaryCondition(f, other, comparerNotLike)
+}
+
+func (f *FuncCol[T]) NotLikeParam(pattern string) Condition {
+	return NewBinaryParamCondition(f, pattern, comparerNotLike)
+}
+
+func (f *FuncCol[T]) Between(low, high ParametricSql) Condition {
+	return newBetweenCondition(f, low, high)
+}
+
+func (f *FuncCol[T]) BetweenParam(low, high T) Condition {
+	return newBetweenCondition(f, Param(low), Param(high))
+}
+
+func (f *FuncCol[T]) IsDistinctFrom(other ParametricSql) Condition {
+	return NewBinaryCondition(f, other, comparerDistinctFrom)
+}
+
+func (f *FuncCol[T]) IsDistinctFromParam(other T) Condition {
+	return NewBinaryParamCondition(f, other, comparerDistinctFrom)
+}
+
+func (f *FuncCol[T]) IsNotDistinctFrom(other ParametricSql) Condition {
+	return NewBinaryCondition(f, other, comparerNotDistinctFrom)
+}
+
+func (f *FuncCol[T]) IsNotDistinctFromParam(other T) Condition {
+	return NewBinaryParamCondition(f, other, comparerNotDistinctFrom)
+}
+
 func (f *FuncCol[T]) IsNull() Condition {
 	return newIsCondition(f, comparerNull)
 }
@@ -219,6 +260,18 @@ func (f *FuncCol[T]) In(sqlable ParametricSql) Condition {
 	return newInCondition(f, sqlable)
 }
 
+func (f *FuncCol[T]) NotIn(sqlable ParametricSql) Condition {
+	return newNotInCondition(f, sqlable)
+}
+
+func (f *FuncCol[T]) InParams(values ...T) Condition {
+	return newInParamsCondition(f, values, false)
+}
+
+func (f *FuncCol[T]) NotInParams(values ...T) Condition {
+	return newInParamsCondition(f, values, true)
+}
+
 func (f *FuncCol[T]) EqAny(sqlable ParametricSql) Condition {
 	return newAnyCondition(f, comparerEq, sqlable)
 }
@@ -268,77 +321,85 @@ func newFuncCol[T any](funcName string, inner ParametricSql) *FuncCol[T] {
 	}
 }
 
-func (f *FuncCol[T]) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
+// newExprCol returns a column for an expression that is not a function call, e.g. a CASE or a CAST. It renders inner
+// as is, without a function name around it, and can be aliased, compared and sorted like a function column.
+func newExprCol[T any](inner ParametricSql) *FuncCol[T] {
+	return newFuncCol[T]("", inner)
+}
+
+func (f *FuncCol[T]) SqlWithParams(paramsMap *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	switch ctx {
 	case DefinitionContext:
-		sql := f.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = f.inner.SqlWithParams(paramsMap, ctx)
-		sql += innerSql + ")"
+		var sql string
+		sql, paramsMap = f.callSql(paramsMap, ctx)
 		// Only include alias in SELECT context
 		if f.Alias() != nil {
-			sql += " AS " + *f.Alias()
+			sql += " AS " + quoteIdent(paramsMap.Dialect(), *f.Alias())
 		}
 		return sql, paramsMap
 	case ReferenceContext:
-		sql := f.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = f.inner.SqlWithParams(paramsMap, ctx)
-		sql += innerSql + ")"
-		return sql, paramsMap
+		return f.callSql(paramsMap, ctx)
 	case OrderByContext:
 		// In ORDER BY context, if there's an alias, return just the alias
 		if f.Alias() != nil {
-			return *f.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *f.Alias()), paramsMap
 		}
 		// Otherwise return the full function expression
-		sql := f.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = f.inner.SqlWithParams(paramsMap, ctx)
-		sql += innerSql + ")"
-		return sql, paramsMap
+		return f.callSql(paramsMap, ctx)
 	default:
 		panic(fmt.Sprintf("FuncCol.SqlWithParams: unexpected RenderContext %s", ctx))
 	}
 }
 
+// callSql renders the function call, followed by its OVER clause if any. The arguments are rendered in innerCtx.
+func (f *FuncCol[T]) callSql(paramsMap *ParamsMap, innerCtx RenderContext) (string, *ParamsMap) {
+	inner := f.inner
+	d := paramsMap.Dialect()
+	nativeFilter := f.filter != nil && d.Supports(FeatureFilter)
+	if f.filter != nil && !nativeFilter {
+		inner = filteredArgs(d, inner, f.filter)
+	}
+	innerSql, paramsMap := inner.SqlWithParams(paramsMap, innerCtx)
+	sql := innerSql
+	if f.funcName != "" {
+		sql = d.FunctionName(f.funcName) + "(" + innerSql + ")"
+	}
+	if nativeFilter {
+		sql += " FILTER (WHERE " + f.filter.SQL(paramsMap) + ")"
+	}
+	if f.over != nil {
+		var overSql string
+		overSql, paramsMap = f.over.overSql(paramsMap)
+		sql += overSql
+	}
+	return sql, paramsMap
+}
+
 // funcColRefWrapper renders a function column reference (just the alias if present, or the full function expression)
 type funcColRefWrapper[T any] struct {
 	funcCol *FuncCol[T]
 }
 
-func (fcrw funcColRefWrapper[T]) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (fcrw funcColRefWrapper[T]) SqlWithParams(paramsMap *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	switch ctx {
 	case DefinitionContext:
 		if fcrw.funcCol.Alias() != nil {
-			return *fcrw.funcCol.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *fcrw.funcCol.Alias()), paramsMap
 		}
 		// If no alias, render the full function expression without " AS ..."
-		sql := fcrw.funcCol.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = fcrw.funcCol.inner.SqlWithParams(paramsMap, OrderByContext)
-		sql += innerSql + ")"
-		return sql, paramsMap
+		return fcrw.funcCol.callSql(paramsMap, OrderByContext)
 	case ReferenceContext:
 		if fcrw.funcCol.Alias() != nil {
-			return *fcrw.funcCol.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *fcrw.funcCol.Alias()), paramsMap
 		}
 		// If no alias, render the full function expression without " AS ..."
-		sql := fcrw.funcCol.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = fcrw.funcCol.inner.SqlWithParams(paramsMap, OrderByContext)
-		sql += innerSql + ")"
-		return sql, paramsMap
+		return fcrw.funcCol.callSql(paramsMap, OrderByContext)
 	case OrderByContext:
 		if fcrw.funcCol.Alias() != nil {
-			return *fcrw.funcCol.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *fcrw.funcCol.Alias()), paramsMap
 		}
 		// If no alias, render the full function expression without " AS ..."
-		sql := fcrw.funcCol.funcName + "("
-		var innerSql string
-		innerSql, paramsMap = fcrw.funcCol.inner.SqlWithParams(paramsMap, OrderByContext)
-		sql += innerSql + ")"
-		return sql, paramsMap
+		return fcrw.funcCol.callSql(paramsMap, OrderByContext)
 	default:
 		panic(fmt.Sprintf("funcColRefWrapper.SqlWithParams: unexpected RenderContext %s", ctx))
 	}
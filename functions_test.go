@@ -14,126 +14,126 @@ func TestFuncColumn(t *testing.T) {
 		{
 			want: "COUNT(1)",
 			got: func() string {
-				sql, _ := Count().SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Count().SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(1) AS c1",
 			got: func() string {
-				sql, _ := Count().As("c1").SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Count().As("c1").SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "c1",
 			got: func() string {
-				sql, _ := Count().As("c1").SqlWithParams(ParamsMap{}, OrderByContext)
+				sql, _ := Count().As("c1").SqlWithParams(&ParamsMap{}, OrderByContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(col1)",
 			got: func() string {
-				sql, _ := Count(NewCol[int]("col1", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Count(NewCol[int]("col1", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(col1) AS c2",
 			got: func() string {
-				sql, _ := Count(NewCol[int]("col1", nil)).As("c2").SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Count(NewCol[int]("col1", nil)).As("c2").SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(DISTINCT col1)",
 			got: func() string {
-				sql, _ := CountDistinct(NewCol[int]("col1", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := CountDistinct(NewCol[int]("col1", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(DISTINCT col1) AS cd1",
 			got: func() string {
-				sql, _ := CountDistinct(NewCol[int]("col1", nil)).As("cd1").SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := CountDistinct(NewCol[int]("col1", nil)).As("cd1").SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(DISTINCT col1, col2)",
 			got: func() string {
-				sql, _ := CountDistinct(NewCol[int]("col1", nil), NewCol[int]("col2", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := CountDistinct(NewCol[int]("col1", nil), NewCol[int]("col2", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "COUNT(DISTINCT col1, col2, col3) AS cd2",
 			got: func() string {
-				sql, _ := CountDistinct(NewCol[int]("col1", nil), NewCol[int]("col2", nil), NewCol[int]("col3", nil)).As("cd2").SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := CountDistinct(NewCol[int]("col1", nil), NewCol[int]("col2", nil), NewCol[int]("col3", nil)).As("cd2").SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "EXISTS(SELECT 1)",
 			got: func() string {
-				sql, _ := Exists(Select(NewFixedCol(1, nil))).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Exists(Select(NewFixedCol(1, nil))).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "EXISTS(SELECT 1) AS e1",
 			got: func() string {
-				sql, _ := Exists(Select(NewFixedCol(1, nil))).As("e1").SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Exists(Select(NewFixedCol(1, nil))).As("e1").SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "SUM(col1)",
 			got: func() string {
-				sql, _ := Sum[int](NewCol[int]("col1", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Sum[int](NewCol[int]("col1", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "AVG(col2)",
 			got: func() string {
-				sql, _ := Avg[float64](NewCol[float64]("col2", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Avg[float64](NewCol[float64]("col2", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "MIN(col3)",
 			got: func() string {
-				sql, _ := Min[int](NewCol[int]("col3", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Min[int](NewCol[int]("col3", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "MAX(col4)",
 			got: func() string {
-				sql, _ := Max[int](NewCol[int]("col4", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Max[int](NewCol[int]("col4", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "UPPER(col5)",
 			got: func() string {
-				sql, _ := Upper(NewCol[string]("col5", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Upper(NewCol[string]("col5", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "LOWER(col6)",
 			got: func() string {
-				sql, _ := Lower(NewCol[string]("col6", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Lower(NewCol[string]("col6", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "LENGTH(col7)",
 			got: func() string {
-				sql, _ := Length(NewCol[string]("col7", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Length(NewCol[string]("col7", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
@@ -144,28 +144,28 @@ func TestFuncColumn(t *testing.T) {
 					NewCol[string]("col8", nil),
 					NewCol[string]("col9", nil),
 					NewCol[string]("col10", nil),
-				).SqlWithParams(ParamsMap{}, DefinitionContext)
+				).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "ROUND(col11, 2)",
 			got: func() string {
-				sql, _ := Round(NewCol[float64]("col11", nil), 2).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Round(NewCol[float64]("col11", nil), 2).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "ABS(col12)",
 			got: func() string {
-				sql, _ := Abs[int](NewCol[int]("col12", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Abs[int](NewCol[int]("col12", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
 		{
 			want: "TRIM(col13)",
 			got: func() string {
-				sql, _ := Trim(NewCol[string]("col13", nil)).SqlWithParams(ParamsMap{}, DefinitionContext)
+				sql, _ := Trim(NewCol[string]("col13", nil)).SqlWithParams(&ParamsMap{}, DefinitionContext)
 				return sql
 			},
 		},
@@ -200,9 +200,9 @@ func TestFuncCol_Comparisons(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			countFunc := Count(NewCol[int]("col1", nil))
-			cond := tt.testFn(countFunc)
+			cond := tt.testFn(countFunc.FuncCol)
 
-			sql := cond.SQL(ParamsMap{})
+			sql := cond.SQL(&ParamsMap{})
 			require.Equal(t, "COUNT(col1) "+tt.operator+" col1", sql)
 		})
 	}
@@ -225,12 +225,12 @@ func TestFuncCol_ComparisonsParam(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			countFunc := Count(NewCol[int]("col1", nil))
-			cond := tt.testFn(countFunc)
+			cond := tt.testFn(countFunc.FuncCol)
 
-			params := ParamsMap{}
+			params := &ParamsMap{}
 			sql := cond.SQL(params)
 			require.Equal(t, "COUNT(col1) "+tt.operator+" "+GetDialect().Placeholder(1), sql)
-			require.Equal(t, ParamsMap{10: 1}, params)
+			require.Equal(t, []any{10}, params.ToSlice())
 		})
 	}
 }
@@ -241,7 +241,7 @@ func TestFuncCol_NullChecks(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil))
 		cond := countFunc.IsNull()
 
-		sql := cond.SQL(ParamsMap{})
+		sql := cond.SQL(&ParamsMap{})
 		require.Equal(t, "COUNT(col1) IS NULL", sql)
 	})
 
@@ -249,7 +249,7 @@ func TestFuncCol_NullChecks(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil))
 		cond := countFunc.IsNotNull()
 
-		sql := cond.SQL(ParamsMap{})
+		sql := cond.SQL(&ParamsMap{})
 		require.Equal(t, "COUNT(col1) IS NOT NULL", sql)
 	})
 }
@@ -263,7 +263,7 @@ func TestFuncCol_In(t *testing.T) {
 
 	cond := countFunc.In(subquery)
 
-	params := ParamsMap{}
+	params := &ParamsMap{}
 	sql := cond.SQL(params)
 	require.Equal(t, "COUNT(col1) IN (SELECT table1.col2 FROM table1)", sql)
 }
@@ -274,7 +274,7 @@ func TestFuncCol_Like(t *testing.T) {
 		upperFunc := Upper(NewCol[string]("col1", nil))
 		cond := upperFunc.Like(NewCol[string]("col2", nil))
 
-		sql := cond.SQL(ParamsMap{})
+		sql := cond.SQL(&ParamsMap{})
 		require.Equal(t, "UPPER(col1) LIKE col2", sql)
 	})
 
@@ -282,10 +282,10 @@ func TestFuncCol_Like(t *testing.T) {
 		upperFunc := Upper(NewCol[string]("col1", nil))
 		cond := upperFunc.LikeParam("%test%")
 
-		params := ParamsMap{}
+		params := &ParamsMap{}
 		sql := cond.SQL(params)
 		require.Equal(t, "UPPER(col1) LIKE "+GetDialect().Placeholder(1), sql)
-		require.Equal(t, ParamsMap{"%test%": 1}, params)
+		require.Equal(t, []any{"%test%"}, params.ToSlice())
 	})
 }
 
@@ -304,7 +304,7 @@ func TestFuncCol_Alias(t *testing.T) {
 
 	t.Run("Alias in SQL", func(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil)).As("cnt")
-		sql, _ := countFunc.SqlWithParams(ParamsMap{}, DefinitionContext)
+		sql, _ := countFunc.SqlWithParams(&ParamsMap{}, DefinitionContext)
 		require.Equal(t, "COUNT(col1) AS cnt", sql)
 	})
 }
@@ -315,7 +315,7 @@ func TestFuncCol_Sorting(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil)).As("cnt")
 		sortCol := countFunc.Asc()
 
-		sql, _ := sortCol.SqlWithParams(ParamsMap{}, OrderByContext)
+		sql, _ := sortCol.SqlWithParams(&ParamsMap{}, OrderByContext)
 		require.Equal(t, "cnt ASC", sql)
 	})
 
@@ -323,7 +323,7 @@ func TestFuncCol_Sorting(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil)).As("cnt")
 		sortCol := countFunc.Desc()
 
-		sql, _ := sortCol.SqlWithParams(ParamsMap{}, OrderByContext)
+		sql, _ := sortCol.SqlWithParams(&ParamsMap{}, OrderByContext)
 		require.Equal(t, "cnt DESC", sql)
 	})
 
@@ -331,7 +331,7 @@ func TestFuncCol_Sorting(t *testing.T) {
 		countFunc := Count(NewCol[int]("col1", nil))
 		sortCol := countFunc.Asc()
 
-		sql, _ := sortCol.SqlWithParams(ParamsMap{}, OrderByContext)
+		sql, _ := sortCol.SqlWithParams(&ParamsMap{}, OrderByContext)
 		require.Equal(t, "COUNT(col1) ASC", sql)
 	})
 }
@@ -363,9 +363,9 @@ func TestFuncCol_QuantifiedComparisons(t *testing.T) {
 			countFunc := Count(col1)
 			subquery := Select(col2).From(table).AsSubQuery()
 
-			cond := tt.testFn(countFunc, subquery)
+			cond := tt.testFn(countFunc.FuncCol, subquery)
 
-			params := ParamsMap{}
+			params := &ParamsMap{}
 			sql := cond.SQL(params)
 			require.Equal(t, "COUNT(table1.col1) "+tt.operator+"(SELECT table1.col2 FROM table1)", sql)
 		})
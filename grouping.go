@@ -0,0 +1,50 @@
+package tomasql
+
+import "strings"
+
+// Rollup groups by the given expressions and by each of their prefixes, down to the grand total, e.g.
+// ROLLUP (a, b) is GROUPING SETS ((a, b), (a), ()). Use GroupingSet to treat several expressions as a unit.
+func Rollup(first ParametricSql, others ...ParametricSql) ParametricSql {
+	return &groupingSql{keyword: "ROLLUP ", exprs: append([]ParametricSql{first}, others...)}
+}
+
+// Cube groups by every combination of the given expressions, e.g. CUBE (a, b) is
+// GROUPING SETS ((a, b), (a), (b), ()).
+func Cube(first ParametricSql, others ...ParametricSql) ParametricSql {
+	return &groupingSql{keyword: "CUBE ", exprs: append([]ParametricSql{first}, others...)}
+}
+
+// GroupingSets groups by each of the given sets separately. Sets are single expressions, GroupingSet lists, Rollup
+// or Cube.
+func GroupingSets(first ParametricSql, others ...ParametricSql) ParametricSql {
+	return &groupingSql{keyword: "GROUPING SETS ", exprs: append([]ParametricSql{first}, others...)}
+}
+
+// GroupingSet is a parenthesized list of grouping expressions, e.g. (a, b). Without expressions it is the empty set
+// (), which groups all rows together.
+func GroupingSet(exprs ...ParametricSql) ParametricSql {
+	return &groupingSql{exprs: exprs}
+}
+
+// Grouping returns a bit mask telling which of the given GROUP BY expressions are not grouped in the current row: the
+// bit of an expression (the last one being the least significant) is set when the row is a subtotal over it.
+func Grouping(col ParametricSql, cols ...ParametricSql) *FuncCol[int] {
+	return newFuncCol[int]("GROUPING", newMultiParametricSql(", ", append([]ParametricSql{col}, cols...)...))
+}
+
+// groupingSql renders `keyword(exprs)`.
+type groupingSql struct {
+	keyword string
+	exprs   []ParametricSql
+}
+
+func (g *groupingSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	if g.keyword != "" {
+		requireFeature(params.Dialect(), FeatureGroupingSets)
+	}
+	exprsSql := make([]string, len(g.exprs))
+	for i, expr := range g.exprs {
+		exprsSql[i], params = expr.SqlWithParams(params, ReferenceContext)
+	}
+	return g.keyword + "(" + strings.Join(exprsSql, ", ") + ")", params
+}
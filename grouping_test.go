@@ -0,0 +1,75 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupBy_GroupingSets(t *testing.T) {
+	t.Run("rollup", func(t *testing.T) {
+		sql, _ := Select(Account.Type, Config.Uuid, Count().As("total")).
+			From(Account).
+			Join(Config).On(Config.AccountId.Eq(Account.Id)).
+			GroupBy(Rollup(Account.Type, Config.Uuid)).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, config.uuid, COUNT(1) AS total FROM account "+
+			"JOIN config ON config.account_id = account.id GROUP BY ROLLUP (account.type, config.uuid)", sql)
+	})
+
+	t.Run("cube with composite set", func(t *testing.T) {
+		sql, _ := Select(Count()).From(Account).
+			GroupBy(Cube(Account.Type, GroupingSet(Account.Uuid, Account.CreatedTs))).
+			SQL()
+
+		require.Equal(t, "SELECT COUNT(1) FROM account GROUP BY CUBE (account.type, (account.uuid, account.created_ts))", sql)
+	})
+
+	t.Run("grouping sets", func(t *testing.T) {
+		sql, _ := Select(Account.Type, Account.Uuid, Count()).From(Account).
+			GroupBy(GroupingSets(Account.Type, GroupingSet(Account.Type, Account.Uuid), GroupingSet())).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, account.uuid, COUNT(1) FROM account "+
+			"GROUP BY GROUPING SETS (account.type, (account.type, account.uuid), ())", sql)
+	})
+
+	t.Run("mixed with plain expressions", func(t *testing.T) {
+		sql, _ := Select(Count()).From(Account).
+			GroupBy(Account.Type, Rollup(Account.Uuid)).
+			SQL()
+
+		require.Equal(t, "SELECT COUNT(1) FROM account GROUP BY account.type, ROLLUP (account.uuid)", sql)
+	})
+}
+
+func TestGrouping(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureGroupingSets}})
+
+	level := Grouping(Account.Type, Account.Uuid)
+	sql, params := Select(Account.Type, Account.Uuid, level.As("level"), Count().As("total")).
+		From(Account).
+		GroupBy(Rollup(Account.Type, Account.Uuid)).
+		Having(level.LtParam(3)).
+		OrderBy(level.Asc()).
+		SQL()
+
+	require.Equal(t, "SELECT account.type, account.uuid, GROUPING(account.type, account.uuid) AS level, COUNT(1) AS total "+
+		"FROM account GROUP BY ROLLUP (account.type, account.uuid) "+
+		"HAVING GROUPING(account.type, account.uuid) < $1 ORDER BY level ASC", sql)
+	require.Equal(t, []any{3}, params)
+}
+
+func TestGroupingSets_Unsupported(t *testing.T) {
+	withDialect(t, &numberedTestDialect{})
+
+	query := Select(Count()).From(Account).GroupBy(Cube(Account.Type))
+	require.PanicsWithError(t, "tomasql: GROUPING SETS is not supported by the numbered dialect", func() {
+		query.SQL()
+	})
+
+	// a plain parenthesized list is a valid GROUP BY in every dialect
+	sql, _ := Select(Count()).From(Account).GroupBy(GroupingSet(Account.Type, Account.Uuid)).SQL()
+	require.Equal(t, "SELECT COUNT(1) FROM account GROUP BY (account.type, account.uuid)", sql)
+}
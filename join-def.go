@@ -1,17 +1,25 @@
 package tomasql
 
+import "strings"
+
 type JoinType string
 
 const (
 	LeftJoin  = JoinType("LEFT")
 	InnerJoin = JoinType("")
 	RightJoin = JoinType("RIGHT")
+	FullJoin  = JoinType("FULL")
+	CrossJoin = JoinType("CROSS")
 )
 
 type joinDef struct {
 	joinType      JoinType
 	joinTable     Table
 	joinCondition Condition
+	// usingColumns are the columns of a USING clause, alternative to joinCondition
+	usingColumns []Column
+	// lateral joins can reference the tables that precede them
+	lateral bool
 }
 
 var _ ParametricSql = &joinDef{}
@@ -24,15 +32,39 @@ func newJoinDef(joinType JoinType, joinTable Table, cond Condition) *joinDef {
 	}
 }
 
-func (j *joinDef) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (j *joinDef) SqlWithParams(paramsMap *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	switch j.joinType {
+	case RightJoin:
+		requireFeature(paramsMap.Dialect(), FeatureRightJoin)
+	case FullJoin:
+		requireFeature(paramsMap.Dialect(), FeatureFullJoin)
+	}
+	if j.lateral {
+		requireFeature(paramsMap.Dialect(), FeatureLateral)
+	}
+	if len(j.usingColumns) > 0 {
+		requireFeature(paramsMap.Dialect(), FeatureJoinUsing)
+	}
+
 	joinStr := ""
 	if j.joinType != "" {
 		joinStr += string(j.joinType) + " "
 	}
 	joinTableSql, paramsMap := j.joinTable.SqlWithParams(paramsMap, ReferenceContext)
-	joinStr += "JOIN " + joinTableSql
+	joinStr += "JOIN "
+	if j.lateral {
+		joinStr += "LATERAL "
+	}
+	joinStr += joinTableSql
 	if j.joinCondition != nil {
 		joinStr += " ON " + j.joinCondition.SQL(paramsMap)
 	}
+	if len(j.usingColumns) > 0 {
+		names := make([]string, len(j.usingColumns))
+		for i, col := range j.usingColumns {
+			names[i] = quoteIdent(paramsMap.Dialect(), col.Name())
+		}
+		joinStr += " USING (" + strings.Join(names, ", ") + ")"
+	}
 	return joinStr, paramsMap
 }
@@ -0,0 +1,74 @@
+package tomasql
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Param returns a placeholder expression for value, which is passed to the database as a query parameter.
+func Param[T any](value T) Expression[T] {
+	return &paramSql[T]{value: value}
+}
+
+// Param returns a placeholder expression for a value of the column's type.
+func (c Col[T]) Param(value T) Expression[T] {
+	return &paramSql[T]{value: value}
+}
+
+// Literal returns an expression that renders value inline in the SQL, e.g. 'text', 42 or TRUE.
+func Literal[T ~string | Number | ~bool](value T) Expression[T] {
+	return &literalSql[T]{value: value}
+}
+
+type literalSql[T ~string | Number | ~bool] struct {
+	value T
+}
+
+var _ Expression[string] = &literalSql[string]{}
+
+func (l *literalSql[T]) valueType() T {
+	var zero T
+	return zero
+}
+
+func (l *literalSql[T]) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	value := reflect.ValueOf(l.value)
+	switch value.Kind() {
+	case reflect.String:
+		return params.Dialect().StringLiteral(value.String()), params
+	case reflect.Bool:
+		return params.Dialect().BoolLiteral(value.Bool()), params
+	default:
+		return fmt.Sprintf("%v", l.value), params
+	}
+}
+
+// placeholder is implemented by the values that render as a query parameter.
+type placeholder interface {
+	placeholder()
+}
+
+type paramSql[T any] struct {
+	value T
+}
+
+var (
+	_ Expression[int] = &paramSql[int]{}
+	_ placeholder     = &paramSql[int]{}
+)
+
+func (p *paramSql[T]) valueType() T {
+	var zero T
+	return zero
+}
+
+func (p *paramSql[T]) placeholder() {}
+
+func (p *paramSql[T]) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	return params.Placeholder(p.value), params
+}
+
+// sameColumn reports whether a and b reference the same column of the same table.
+func sameColumn(a, b Column) bool {
+	return a.Name() == b.Name() && a.Table() == b.Table()
+}
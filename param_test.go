@@ -0,0 +1,75 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParam(t *testing.T) {
+	t.Run("renders placeholder and registers value", func(t *testing.T) {
+		params := &ParamsMap{}
+		sql, params := Param(42).SqlWithParams(params, ReferenceContext)
+		require.Equal(t, "?", sql)
+		require.Equal(t, []any{42}, params.ToSlice())
+	})
+
+	t.Run("reuses position of existing value", func(t *testing.T) {
+		params := NewParamsMap(&numberedTestDialect{})
+		params.Add("a")
+		params.Add(42)
+		sql, params := Account.CreatedTs.Param(42).SqlWithParams(params, ReferenceContext)
+		require.Equal(t, "$2", sql)
+		require.Equal(t, []any{"a", 42}, params.ToSlice())
+	})
+
+	t.Run("positional placeholders repeat the value", func(t *testing.T) {
+		params := NewParamsMap(&StandardDialect{})
+		params.Add(42)
+		_, params = Account.CreatedTs.Param(42).SqlWithParams(params, ReferenceContext)
+		require.Equal(t, []any{42, 42}, params.ToSlice())
+	})
+}
+
+func TestLiteral(t *testing.T) {
+	type status string
+
+	tests := []struct {
+		name     string
+		literal  ParametricSql
+		expected string
+	}{
+		{"int", Literal(42), "42"},
+		{"float", Literal(1.5), "1.5"},
+		{"string", Literal("active"), "'active'"},
+		{"string with quote", Literal("it's"), "'it''s'"},
+		{"named string type", Literal(status("new")), "'new'"},
+		{"true", Literal(true), "TRUE"},
+		{"false", Literal(false), "FALSE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, params := tt.literal.SqlWithParams(&ParamsMap{}, ReferenceContext)
+			require.Equal(t, tt.expected, sql)
+			require.Empty(t, params.ToSlice())
+		})
+	}
+}
+
+func TestParamsMap_Add(t *testing.T) {
+	t.Run("numbered placeholders", func(t *testing.T) {
+		params := NewParamsMap(&numberedTestDialect{})
+		require.Equal(t, 1, params.Add("a"))
+		require.Equal(t, 2, params.Add("b"))
+		require.Equal(t, 1, params.Add("a"))
+		require.Equal(t, []any{"a", "b"}, params.ToSlice())
+	})
+
+	t.Run("positional placeholders", func(t *testing.T) {
+		params := NewParamsMap(&StandardDialect{})
+		require.Equal(t, 1, params.Add("a"))
+		require.Equal(t, 2, params.Add("b"))
+		require.Equal(t, 3, params.Add("a"))
+		require.Equal(t, []any{"a", "b", "a"}, params.ToSlice())
+	})
+}
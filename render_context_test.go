@@ -10,7 +10,7 @@ import (
 func TestColSqlWithParams_RenderContexts(t *testing.T) {
 	table := &simpleTable{name: "users"}
 	col := NewCol[string]("username", table)
-	params := ParamsMap{}
+	params := &ParamsMap{}
 
 	t.Run("without alias", func(t *testing.T) {
 		tests := []struct {
@@ -102,7 +102,7 @@ func TestColSqlWithParams_RenderContexts(t *testing.T) {
 
 // TestTableSqlWithParams_RenderContexts tests Table.SqlWithParams with different RenderContext values
 func TestTableSqlWithParams_RenderContexts(t *testing.T) {
-	params := ParamsMap{}
+	params := &ParamsMap{}
 
 	t.Run("sqlableTable", func(t *testing.T) {
 		tests := []struct {
@@ -170,7 +170,7 @@ func TestTableSqlWithParams_RenderContexts(t *testing.T) {
 func TestFuncColSqlWithParams_RenderContexts(t *testing.T) {
 	table := &simpleTable{name: "orders"}
 	col := NewCol[int]("amount", table)
-	params := ParamsMap{}
+	params := &ParamsMap{}
 
 	t.Run("function with alias - OrderByContext returns only alias", func(t *testing.T) {
 		funcCol := Sum[int](col).As("total_amount")
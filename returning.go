@@ -0,0 +1,17 @@
+package tomasql
+
+import "strings"
+
+// renderReturning renders the RETURNING clause of a write statement, or an empty string if no columns are returned.
+func renderReturning(columns []Column, params *ParamsMap) (string, *ParamsMap) {
+	if len(columns) == 0 {
+		return "", params
+	}
+	requireFeature(params.Dialect(), FeatureReturning)
+
+	colsSql := make([]string, len(columns))
+	for i, col := range columns {
+		colsSql[i], params = col.SqlWithParams(params, DefinitionContext)
+	}
+	return " RETURNING " + strings.Join(colsSql, ", "), params
+}
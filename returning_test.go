@@ -0,0 +1,74 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturning(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureReturning, FeatureUpdateFrom}})
+
+	t.Run("insert", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.CreatedTs).
+			Values(Account.Uuid.Value("u1"), Account.CreatedTs.Value(10)).
+			Returning(Account.Id, Account.CreatedTs).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, created_ts) VALUES ($1, $2) RETURNING account.id, account.created_ts", sql)
+		require.Equal(t, []any{"u1", 10}, params)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		sql, params := Update(Config).
+			Set(Config.ArchivedTs.SetParam(5)).
+			Where(Config.Id.EqParam(int64(1))).
+			Returning(Config.Id.As("config_id")).
+			SQL()
+
+		require.Equal(t, "UPDATE config SET archived_ts = $1 WHERE config.id = $2 RETURNING config.id AS config_id", sql)
+		require.Equal(t, []any{5, int64(1)}, params)
+	})
+
+	t.Run("update without where", func(t *testing.T) {
+		sql, _ := Update(Config).Set(Config.ArchivedTs.SetNull()).Returning(Config.Id).SQL()
+		require.Equal(t, "UPDATE config SET archived_ts = NULL RETURNING config.id", sql)
+	})
+
+	t.Run("update from", func(t *testing.T) {
+		sql, _ := Update(Config).
+			Set(Config.ArchivedTs.Set(Account.CreatedTs)).
+			From(Account).
+			Returning(Config.Id, Account.Uuid).
+			SQL()
+		require.Equal(t, "UPDATE config SET archived_ts = account.created_ts FROM account RETURNING config.id, account.uuid", sql)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		sql, params := DeleteFrom(Account).
+			Where(Account.Uuid.EqParam("u1")).
+			Returning(Account.Id).
+			SQL()
+
+		require.Equal(t, "DELETE FROM account WHERE account.uuid = $1 RETURNING account.id", sql)
+		require.Equal(t, []any{"u1"}, params)
+	})
+
+	t.Run("delete all", func(t *testing.T) {
+		sql, _ := DeleteFrom(Account).All().Returning(Account.Id).SQL()
+		require.Equal(t, "DELETE FROM account RETURNING account.id", sql)
+	})
+}
+
+func TestReturning_UnsupportedDialect(t *testing.T) {
+	withDialect(t, DefaultDialect)
+
+	require.PanicsWithError(t, "tomasql: RETURNING is not supported by the standard dialect", func() {
+		DeleteFrom(Account).All().Returning(Account.Id).SQL()
+	})
+
+	require.PanicsWithError(t, "tomasql: RETURNING is not supported by the standard dialect", func() {
+		InsertInto(Account).Columns(Account.Uuid).Values(Account.Uuid.Value("u1")).Returning(Account.Id).SQL()
+	})
+}
@@ -29,24 +29,24 @@ func NewSqlableTable(t Table) *sqlableTable {
 	return newSqlableTable(t)
 }
 
-func (s *sqlableTable) SqlWithParams(params ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (s *sqlableTable) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	switch ctx {
 	case DefinitionContext:
-		tRef := s.table.TableName()
+		tRef := quoteIdent(params.Dialect(), s.table.TableName())
 		if s.table.Alias() != nil {
-			tRef += " AS " + *s.table.Alias()
+			tRef += " AS " + quoteIdent(params.Dialect(), *s.table.Alias())
 		}
 		return tRef, params
 	case ReferenceContext:
-		tRef := s.table.TableName()
+		tRef := quoteIdent(params.Dialect(), s.table.TableName())
 		if s.table.Alias() != nil {
-			tRef += " AS " + *s.table.Alias()
+			tRef += " AS " + quoteIdent(params.Dialect(), *s.table.Alias())
 		}
 		return tRef, params
 	case OrderByContext:
-		tRef := s.table.TableName()
+		tRef := quoteIdent(params.Dialect(), s.table.TableName())
 		if s.table.Alias() != nil {
-			tRef += " AS " + *s.table.Alias()
+			tRef += " AS " + quoteIdent(params.Dialect(), *s.table.Alias())
 		}
 		return tRef, params
 	default:
@@ -64,16 +64,29 @@ type tableDef struct {
 var _ Table = &tableDef{}
 
 func NewTableFromSubQuery(subQuery SQLable, alias string, columns []Column) (Table, []Column) {
-	table := &tableDef{
+	table := newTableDef(subQuery, alias)
+	table.deriveColumns(table, columns, false)
+	return table, table.columns
+}
+
+func newTableDef(subQuery SQLable, alias string) *tableDef {
+	return &tableDef{
 		withOptionalAlias: newWithOptionalAlias(subQuery, &alias),
 		columns:           []Column{},
 	}
+}
 
+// deriveColumns adds to the table a column of owner (the table itself, or a type embedding it) for each of the given
+// columns. The new columns keep the names of the given ones, and also their types if keepTypes is set; otherwise they
+// are Col[any].
+func (t *tableDef) deriveColumns(owner Table, columns []Column, keepTypes bool) {
 	for _, col := range columns {
-		table.columns = append(table.columns, NewCol[any](col.Name(), table))
+		if keepTypes {
+			t.columns = append(t.columns, col.derive(owner))
+		} else {
+			t.columns = append(t.columns, NewCol[any](col.Name(), owner))
+		}
 	}
-
-	return table, table.columns
 }
 
 // tableRefWrapper is a simple wrapper around a Table that renders a table
@@ -88,23 +101,23 @@ func (t *tableRefWrapper) Alias() *string {
 }
 
 // SqlWithParams implements Table.
-func (t *tableRefWrapper) SqlWithParams(paramsMap ParamsMap, ctx RenderContext) (string, ParamsMap) {
+func (t *tableRefWrapper) SqlWithParams(paramsMap *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
 	switch ctx {
 	case DefinitionContext:
 		if t.table.Alias() != nil {
-			return *t.table.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *t.table.Alias()), paramsMap
 		}
-		return t.table.TableName(), paramsMap
+		return quoteIdent(paramsMap.Dialect(), t.table.TableName()), paramsMap
 	case ReferenceContext:
 		if t.table.Alias() != nil {
-			return *t.table.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *t.table.Alias()), paramsMap
 		}
-		return t.table.TableName(), paramsMap
+		return quoteIdent(paramsMap.Dialect(), t.table.TableName()), paramsMap
 	case OrderByContext:
 		if t.table.Alias() != nil {
-			return *t.table.Alias(), paramsMap
+			return quoteIdent(paramsMap.Dialect(), *t.table.Alias()), paramsMap
 		}
-		return t.table.TableName(), paramsMap
+		return quoteIdent(paramsMap.Dialect(), t.table.TableName()), paramsMap
 	default:
 		panic(fmt.Sprintf("tableRefWrapper.SqlWithParams: unexpected RenderContext %s", ctx))
 	}
@@ -0,0 +1,21 @@
+-- SQLite version of tests/tomasql_test/example_schema.sql
+CREATE TABLE account (
+    id INTEGER PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    type TEXT NOT NULL DEFAULT 'basic' CHECK (type IN ('basic', 'vip')),
+    created_ts INTEGER NOT NULL
+);
+CREATE TABLE config (
+    id INTEGER PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    account_id BIGINT NOT NULL REFERENCES account (id),
+    created_ts INTEGER NOT NULL,
+    archived_ts INTEGER
+);
+CREATE TABLE shopping_cart (
+    id INTEGER PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    owner_id BIGINT NOT NULL REFERENCES account (id),
+    created_ts INTEGER NOT NULL,
+    archived_ts INTEGER
+);
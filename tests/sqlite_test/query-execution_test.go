@@ -0,0 +1,179 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"testing"
+
+	. "github.com/sergiobonfiglio/tomasql"
+	"github.com/sergiobonfiglio/tomasql/dialects/sqlite"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed example_schema.sql
+var schema string
+
+// setupDB opens an in-memory SQLite database with the example schema and sets the SQLite dialect for the duration of
+// the test.
+func setupDB(t *testing.T) *sql.DB {
+	originalDialect := GetDialect()
+	t.Cleanup(func() { SetDialect(originalDialect) })
+	sqlite.SetDialect()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	// every connection to :memory: opens a new database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(schema)
+	require.NoError(t, err)
+	return db
+}
+
+// queryInts runs the query and scans the single integer column of every row.
+func queryInts(t *testing.T, db *sql.DB, query SQLable) []int64 {
+	sql, params := query.SQL()
+	rows, err := db.Query(sql, params...)
+	require.NoError(t, err, sql)
+	defer func() { _ = rows.Close() }()
+
+	var values []int64
+	for rows.Next() {
+		var value int64
+		require.NoError(t, rows.Scan(&value))
+		values = append(values, value)
+	}
+	require.NoError(t, rows.Err())
+	return values
+}
+
+// exec runs the query and returns the number of affected rows.
+func exec(t *testing.T, db *sql.DB, query SQLable) int64 {
+	sql, params := query.SQL()
+	res, err := db.Exec(sql, params...)
+	require.NoError(t, err, sql)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	return affected
+}
+
+func TestQueryOnSQLite(t *testing.T) {
+	db := setupDB(t)
+
+	// setup: 4 accounts, the even ones are vip, and a cart for each of the first 3
+	var accountIds []int64
+	for i := range 4 {
+		accountType := "basic"
+		if i%2 == 0 {
+			accountType = "vip"
+		}
+		ids := queryInts(t, db, InsertInto(Account).
+			Columns(Account.Uuid, Account.Type, Account.CreatedTs).
+			Values(Account.Uuid.Value(fmt.Sprintf("account-%d", i)), Account.Type.Value(accountType),
+				Account.CreatedTs.Value(1000+i)).
+			Returning(Account.Id))
+		require.Len(t, ids, 1)
+		accountIds = append(accountIds, ids[0])
+	}
+	for i, accountId := range accountIds[:3] {
+		affected := exec(t, db, InsertInto(ShoppingCart).
+			Columns(ShoppingCart.Uuid, ShoppingCart.OwnerId, ShoppingCart.CreatedTs).
+			Values(ShoppingCart.Uuid.Value(fmt.Sprintf("cart-%d", i)), ShoppingCart.OwnerId.Value(accountId),
+				ShoppingCart.CreatedTs.Value(2000+i)))
+		require.EqualValues(t, 1, affected)
+	}
+
+	t.Run("reused params", func(t *testing.T) {
+		query := Select(Account.Id).From(Account).
+			Where(Account.Id.EqParam(accountIds[1]).
+				And(Account.Id.EqParam(accountIds[1])).
+				And(Account.Uuid.EqParam("account-1")))
+
+		_, params := query.SQL()
+		require.Len(t, params, 2) // only 2 distinct params
+		require.Equal(t, []int64{accountIds[1]}, queryInts(t, db, query))
+	})
+
+	t.Run("limit and offset", func(t *testing.T) {
+		ids := queryInts(t, db, Select(Account.Id).From(Account).
+			OrderBy(Account.CreatedTs.Desc()).
+			Limit(2).
+			Offset(1))
+
+		require.Equal(t, []int64{accountIds[2], accountIds[1]}, ids)
+	})
+
+	t.Run("right and full joins", func(t *testing.T) {
+		ids := queryInts(t, db, Select(Account.Id).From(ShoppingCart).
+			RightJoin(Account).On(ShoppingCart.OwnerId.Eq(Account.Id)).
+			Where(ShoppingCart.Id.IsNull()))
+		require.Equal(t, []int64{accountIds[3]}, ids)
+
+		counts := queryInts(t, db, Select(Count()).From(ShoppingCart).
+			FullJoin(Account).On(ShoppingCart.OwnerId.Eq(Account.Id)))
+		require.Equal(t, []int64{4}, counts)
+	})
+
+	t.Run("null-safe comparison", func(t *testing.T) {
+		// archived_ts is NULL for every cart, so archived_ts <> 3000 would match none of them
+		counts := queryInts(t, db, Select(Count()).From(ShoppingCart).
+			Where(ShoppingCart.ArchivedTs.IsDistinctFromParam(3000)))
+
+		require.Equal(t, []int64{3}, counts)
+	})
+
+	t.Run("filtered aggregate", func(t *testing.T) {
+		counts := queryInts(t, db, Select(Count().Filter(Account.Type.EqParam("vip"))).From(Account))
+
+		require.Equal(t, []int64{2}, counts)
+	})
+
+	t.Run("common table expression", func(t *testing.T) {
+		vip := With("vip", Select(Account.Id).From(Account).Where(Account.Type.EqParam("vip")))
+		vipId := DerivedCol(vip, Account.Id)
+
+		ids := queryInts(t, db, Select(ShoppingCart.Id).From(ShoppingCart).
+			Join(vip).On(vipId.Eq(ShoppingCart.OwnerId)).
+			OrderBy(ShoppingCart.Id.Asc()))
+
+		require.Len(t, ids, 2)
+	})
+
+	t.Run("window function", func(t *testing.T) {
+		ranks := queryInts(t, db, Select(RowNumber().Over(Window().PartitionBy(Account.Type).
+			OrderBy(Account.CreatedTs.Asc()))).
+			From(Account).
+			OrderBy(Account.CreatedTs.Asc()))
+
+		require.Equal(t, []int64{1, 1, 2, 2}, ranks)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		ids := queryInts(t, db, InsertInto(Account).
+			Columns(Account.Uuid, Account.Type, Account.CreatedTs).
+			Values(Account.Uuid.Value("account-0"), Account.Type.Value("basic"), Account.CreatedTs.Value(5000)).
+			OnConflict(Account.Uuid).
+			DoUpdateSet(Account.CreatedTs.Set(Account.CreatedTs.Excluded())).
+			Returning(Account.Id))
+		require.Equal(t, []int64{accountIds[0]}, ids)
+
+		createdTs := queryInts(t, db, Select(Account.CreatedTs).From(Account).Where(Account.Id.EqParam(accountIds[0])))
+		require.Equal(t, []int64{5000}, createdTs)
+	})
+
+	t.Run("update and delete returning", func(t *testing.T) {
+		ids := queryInts(t, db, Update(ShoppingCart).
+			Set(ShoppingCart.ArchivedTs.SetParam(3000)).
+			Where(ShoppingCart.OwnerId.EqParam(accountIds[0])).
+			Returning(ShoppingCart.OwnerId))
+		require.Equal(t, []int64{accountIds[0]}, ids)
+
+		ids = queryInts(t, db, DeleteFrom(ShoppingCart).
+			Where(ShoppingCart.ArchivedTs.IsNotNull()).
+			Returning(ShoppingCart.OwnerId))
+		require.Equal(t, []int64{accountIds[0]}, ids)
+	})
+}
@@ -26,7 +26,7 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "1 = 1",
-					got:  IdentityCond.SQL(ParamsMap{}),
+					got:  IdentityCond.SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -35,27 +35,27 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = shopping_cart.owner_id",
-					got:  Account.Id.Eq(ShoppingCart.OwnerId).SQL(ParamsMap{}),
+					got:  Account.Id.Eq(ShoppingCart.OwnerId).SQL(&ParamsMap{}),
 				},
 				{
 					want: "a.id = s.owner_id",
-					got:  Account.As("a").Id.Eq(ShoppingCart.As("s").OwnerId).SQL(ParamsMap{}),
+					got:  Account.As("a").Id.Eq(ShoppingCart.As("s").OwnerId).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id > shopping_cart.owner_id",
-					got:  Account.Id.Gt(ShoppingCart.OwnerId).SQL(ParamsMap{}),
+					got:  Account.Id.Gt(ShoppingCart.OwnerId).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id >= shopping_cart.owner_id",
-					got:  Account.Id.Ge(ShoppingCart.OwnerId).SQL(ParamsMap{}),
+					got:  Account.Id.Ge(ShoppingCart.OwnerId).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id < shopping_cart.owner_id",
-					got:  Account.Id.Lt(ShoppingCart.OwnerId).SQL(ParamsMap{}),
+					got:  Account.Id.Lt(ShoppingCart.OwnerId).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id <= shopping_cart.owner_id",
-					got:  Account.Id.Le(ShoppingCart.OwnerId).SQL(ParamsMap{}),
+					got:  Account.Id.Le(ShoppingCart.OwnerId).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -64,23 +64,23 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = " + GetDialect().Placeholder(1),
-					got:  Account.Id.EqParam(1).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(1).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id > " + GetDialect().Placeholder(1),
-					got:  Account.Id.GtParam(1).SQL(ParamsMap{}),
+					got:  Account.Id.GtParam(1).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id >= " + GetDialect().Placeholder(1),
-					got:  Account.Id.GeParam(1).SQL(ParamsMap{}),
+					got:  Account.Id.GeParam(1).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id < " + GetDialect().Placeholder(1),
-					got:  Account.Id.LtParam(1).SQL(ParamsMap{}),
+					got:  Account.Id.LtParam(1).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id <= " + GetDialect().Placeholder(1),
-					got:  Account.Id.LeParam(1).SQL(ParamsMap{}),
+					got:  Account.Id.LeParam(1).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -89,7 +89,7 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id IN (SELECT shopping_cart.owner_id FROM shopping_cart)",
-					got:  Account.Id.In(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(ParamsMap{}),
+					got:  Account.Id.In(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -98,7 +98,7 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "EXISTS(SELECT 1)",
-					got:  NewExistsCondition(Select(NewFixedCol(1, nil))).SQL(ParamsMap{}),
+					got:  NewExistsCondition(Select(NewFixedCol(1, nil))).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -107,7 +107,7 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = ANY(SELECT shopping_cart.owner_id FROM shopping_cart)",
-					got:  Account.Id.EqAny(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(ParamsMap{}),
+					got:  Account.Id.EqAny(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -116,7 +116,7 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = ALL(SELECT shopping_cart.owner_id FROM shopping_cart)",
-					got:  Account.Id.EqAll(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(ParamsMap{}),
+					got:  Account.Id.EqAll(Select(ShoppingCart.OwnerId).From(ShoppingCart).AsSubQuery()).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -125,11 +125,11 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " AND account.id = " + GetDialect().Placeholder(1),
-					got:  Account.Id.EqParam(1).And(Account.Id.EqParam(1)).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(1).And(Account.Id.EqParam(1)).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " AND account.id = " + GetDialect().Placeholder(2),
-					got:  Account.Id.EqParam(7).And(Account.Id.EqParam(1)).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(7).And(Account.Id.EqParam(1)).SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -138,18 +138,18 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " AND account.id = " + GetDialect().Placeholder(1),
-					got:  Account.Id.EqParam(1).And(Account.Id.EqParam(1)).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(1).And(Account.Id.EqParam(1)).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " OR account.id = " + GetDialect().Placeholder(2),
-					got:  Account.Id.EqParam(7).Or(Account.Id.EqParam(1)).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(7).Or(Account.Id.EqParam(1)).SQL(&ParamsMap{}),
 				},
 				{
-					want: "account.id = " + GetDialect().Placeholder(1) + " AND account.uuid = " + GetDialect().Placeholder(2) + " OR account.created_ts = " + GetDialect().Placeholder(3),
+					want: "(account.id = " + GetDialect().Placeholder(1) + " AND account.uuid = " + GetDialect().Placeholder(2) + ") OR account.created_ts = " + GetDialect().Placeholder(3),
 					got: Account.Id.EqParam(1).
 						And(Account.Uuid.EqParam("abc")).
 						Or(Account.CreatedTs.EqParam(3)).
-						SQL(ParamsMap{}),
+						SQL(&ParamsMap{}),
 				},
 			},
 		},
@@ -158,21 +158,21 @@ func TestConditions(t *testing.T) {
 			tests: []test{
 				{
 					want: "(account.id = " + GetDialect().Placeholder(1) + " AND account.id = " + GetDialect().Placeholder(2) + ")",
-					got:  Grouped(Account.Id.EqParam(1).And(Account.Id.EqParam(2))).SQL(ParamsMap{}),
+					got:  Grouped(Account.Id.EqParam(1).And(Account.Id.EqParam(2))).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " AND (account.id = " + GetDialect().Placeholder(2) + ")",
-					got:  Account.Id.EqParam(1).And(Grouped(Account.Id.EqParam(2))).SQL(ParamsMap{}),
+					got:  Account.Id.EqParam(1).And(Grouped(Account.Id.EqParam(2))).SQL(&ParamsMap{}),
 				},
 				{
 					want: "(account.id = " + GetDialect().Placeholder(1) + ") AND account.id = " + GetDialect().Placeholder(2),
-					got:  Grouped(Account.Id.EqParam(1)).And(Account.Id.EqParam(2)).SQL(ParamsMap{}),
+					got:  Grouped(Account.Id.EqParam(1)).And(Account.Id.EqParam(2)).SQL(&ParamsMap{}),
 				},
 				{
 					want: "account.id = " + GetDialect().Placeholder(1) + " AND (account.uuid = " + GetDialect().Placeholder(2) + " OR account.created_ts = " + GetDialect().Placeholder(3) + ")",
 					got: Account.Id.EqParam(1).And(
 						Grouped(Account.Uuid.EqParam("abc").Or(Account.CreatedTs.EqParam(3))),
-					).SQL(ParamsMap{}),
+					).SQL(&ParamsMap{}),
 				},
 			},
 		},
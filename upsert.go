@@ -0,0 +1,105 @@
+package tomasql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUpsertWithoutTarget is the panic value used when rendering ON CONFLICT DO UPDATE without conflict columns, which
+// the databases reject: the columns identify the constraint whose conflicts are updated.
+var ErrUpsertWithoutTarget = errors.New("tomasql: ON CONFLICT DO UPDATE needs the conflicting columns, pass them to OnConflict")
+
+// Excluded returns a reference to the value proposed for insertion into the column, to be used in the DoUpdateSet
+// clause of an upsert (EXCLUDED.col).
+func (c Col[T]) Excluded() *Col[T] {
+	return NewCol[T](c.name, excluded)
+}
+
+// excludedTable is the pseudo table holding the rows proposed for insertion in an upsert.
+type excludedTable struct{}
+
+var (
+	_        Table = &excludedTable{}
+	excluded       = &excludedTable{}
+)
+
+// excludedTableName is the name of the pseudo table in Postgres and SQLite. For ON DUPLICATE KEY UPDATE the same name
+// is declared as the row alias of the inserted values, so that references render the same way.
+const excludedTableName = "EXCLUDED"
+
+func (e *excludedTable) TableName() string {
+	return excludedTableName
+}
+
+func (e *excludedTable) Alias() *string {
+	return nil
+}
+
+func (e *excludedTable) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	return quoteIdent(params.Dialect(), excludedTableName), params
+}
+
+type onConflictClause struct {
+	columns []Column
+	// assignments are nil for DO NOTHING
+	assignments []*Assignment
+}
+
+// SqlWithParams renders the upsert clause for the current dialect; insertColumns are the columns of the INSERT.
+func (o *onConflictClause) SqlWithParams(insertColumns []Column, params *ParamsMap) (string, *ParamsMap) {
+	d := params.Dialect()
+	switch {
+	case d.Supports(FeatureOnConflict):
+		return o.onConflictSql(params)
+	case d.Supports(FeatureOnDuplicateKey):
+		return o.onDuplicateKeySql(insertColumns, params)
+	default:
+		panic(&UnsupportedFeatureError{Dialect: d.Name(), Feature: FeatureOnConflict})
+	}
+}
+
+func (o *onConflictClause) onConflictSql(params *ParamsMap) (string, *ParamsMap) {
+	out := " ON CONFLICT"
+	if len(o.columns) > 0 {
+		colNames := make([]string, len(o.columns))
+		for i, col := range o.columns {
+			colNames[i] = quoteIdent(params.Dialect(), col.Name())
+		}
+		out += " (" + strings.Join(colNames, ", ") + ")"
+	}
+	if o.assignments == nil {
+		return out + " DO NOTHING", params
+	}
+	if len(o.columns) == 0 {
+		panic(ErrUpsertWithoutTarget)
+	}
+
+	var setSql string
+	setSql, params = o.assignmentsSql(params)
+	return out + " DO UPDATE SET " + setSql, params
+}
+
+func (o *onConflictClause) onDuplicateKeySql(insertColumns []Column, params *ParamsMap) (string, *ParamsMap) {
+	if o.assignments == nil {
+		// there is no DO NOTHING: a no-op update keeps the existing row without ignoring other errors like INSERT IGNORE
+		col := insertColumns[0]
+		if len(o.columns) > 0 {
+			col = o.columns[0]
+		}
+		name := quoteIdent(params.Dialect(), col.Name())
+		return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", name, name), params
+	}
+
+	var setSql string
+	setSql, params = o.assignmentsSql(params)
+	return " AS " + quoteIdent(params.Dialect(), excludedTableName) + " ON DUPLICATE KEY UPDATE " + setSql, params
+}
+
+func (o *onConflictClause) assignmentsSql(params *ParamsMap) (string, *ParamsMap) {
+	setSql := make([]string, len(o.assignments))
+	for i, assignment := range o.assignments {
+		setSql[i], params = assignment.SqlWithParams(params, ReferenceContext)
+	}
+	return strings.Join(setSql, ", "), params
+}
@@ -0,0 +1,120 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsert_OnConflict(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureOnConflict, FeatureReturning}})
+
+	t.Run("do update set excluded", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.Type).
+			Values(Account.Uuid.Value("u1"), Account.Type.Value("vip")).
+			OnConflict(Account.Uuid).
+			DoUpdateSet(Account.Type.Set(Account.Type.Excluded())).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, type) VALUES ($1, $2) "+
+			"ON CONFLICT (uuid) DO UPDATE SET type = EXCLUDED.type", sql)
+		require.Equal(t, []any{"u1", "vip"}, params)
+	})
+
+	t.Run("do update set params and returning", func(t *testing.T) {
+		sql, params := InsertInto(Account).
+			Columns(Account.Uuid, Account.CreatedTs).
+			Values(Account.Uuid.Value("u1"), Account.CreatedTs.Value(10)).
+			OnConflict(Account.Uuid).
+			DoUpdateSet(Account.CreatedTs.SetParam(20), Account.Type.SetParam("regular")).
+			Returning(Account.Id).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, created_ts) VALUES ($1, $2) "+
+			"ON CONFLICT (uuid) DO UPDATE SET created_ts = $3, type = $4 RETURNING account.id", sql)
+		require.Equal(t, []any{"u1", 10, 20, "regular"}, params)
+	})
+
+	t.Run("do nothing", func(t *testing.T) {
+		sql, _ := InsertInto(Account).
+			Columns(Account.Uuid).
+			Values(Account.Uuid.Value("u1")).
+			OnConflict(Account.Uuid, Account.Type).
+			DoNothing().
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid) VALUES ($1) ON CONFLICT (uuid, type) DO NOTHING", sql)
+	})
+
+	t.Run("do nothing without target", func(t *testing.T) {
+		sql, _ := InsertInto(Account).
+			Columns(Account.Uuid).
+			Values(Account.Uuid.Value("u1")).
+			OnConflict().
+			DoNothing().
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid) VALUES ($1) ON CONFLICT DO NOTHING", sql)
+	})
+
+	t.Run("do update set without target", func(t *testing.T) {
+		query := InsertInto(Account).
+			Columns(Account.Uuid, Account.Type).
+			Values(Account.Uuid.Value("u1"), Account.Type.Value("vip")).
+			OnConflict().
+			DoUpdateSet(Account.Type.Set(Account.Type.Excluded()))
+
+		require.PanicsWithValue(t, ErrUpsertWithoutTarget, func() { query.SQL() })
+
+		_, _, err := query.Build()
+		require.ErrorIs(t, err, ErrUpsertWithoutTarget)
+	})
+}
+
+func TestUpsert_OnDuplicateKey(t *testing.T) {
+	withDialect(t, &numberedTestDialect{features: []Feature{FeatureOnDuplicateKey}})
+
+	t.Run("do update set excluded", func(t *testing.T) {
+		sql, _ := InsertInto(Account).
+			Columns(Account.Uuid, Account.Type).
+			Values(Account.Uuid.Value("u1"), Account.Type.Value("vip")).
+			OnConflict(Account.Uuid).
+			DoUpdateSet(Account.Type.Set(Account.Type.Excluded())).
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, type) VALUES ($1, $2) "+
+			"AS EXCLUDED ON DUPLICATE KEY UPDATE type = EXCLUDED.type", sql)
+	})
+
+	t.Run("do nothing", func(t *testing.T) {
+		sql, _ := InsertInto(Account).
+			Columns(Account.Uuid, Account.Type).
+			Values(Account.Uuid.Value("u1"), Account.Type.Value("vip")).
+			OnConflict().
+			DoNothing().
+			SQL()
+
+		require.Equal(t, "INSERT INTO account (uuid, type) VALUES ($1, $2) ON DUPLICATE KEY UPDATE uuid = uuid", sql)
+	})
+}
+
+func TestUpsert_UnsupportedDialect(t *testing.T) {
+	withDialect(t, DefaultDialect)
+
+	require.PanicsWithError(t, "tomasql: ON CONFLICT is not supported by the standard dialect", func() {
+		InsertInto(Account).
+			Columns(Account.Uuid).
+			Values(Account.Uuid.Value("u1")).
+			OnConflict(Account.Uuid).
+			DoNothing().
+			SQL()
+	})
+}
+
+func TestCol_Excluded(t *testing.T) {
+	var excludedType *Col[string] = Account.Type.Excluded()
+
+	sql, _ := excludedType.SqlWithParams(&ParamsMap{}, ReferenceContext)
+	require.Equal(t, "EXCLUDED.type", sql)
+}
@@ -0,0 +1,95 @@
+package tomasql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValuesRow is a row of a VALUES list.
+type ValuesRow []ParametricSql
+
+// Row returns a row of a VALUES list, usually made of Param or Literal values.
+func Row(first ParametricSql, values ...ParametricSql) ValuesRow {
+	return append(ValuesRow{first}, values...)
+}
+
+// ValuesList is an inline list of rows, e.g. VALUES ($1, $2), ($3, $4). Use As to turn it into a table.
+type ValuesList struct {
+	rows []ValuesRow
+}
+
+// Values starts a VALUES list. All rows must have the same number of values.
+func Values(first ValuesRow, rows ...ValuesRow) *ValuesList {
+	return &ValuesList{rows: append([]ValuesRow{first}, rows...)}
+}
+
+// As turns the list into a table named alias, usable in From and Join. The table columns are named after the given
+// columns and keep their types: the i-th returned column is a Col of the same type as the i-th given one. Use
+// DerivedCol(table, col) to get a typed column from a given one. The parameters of the first row are cast to the SQL
+// type of their column (see Dialect.CastType), as databases such as Postgres would otherwise type them as text.
+func (v *ValuesList) As(alias string, first Column, columns ...Column) (Table, []Column) {
+	columns = append([]Column{first}, columns...)
+	for i, row := range v.rows {
+		if len(row) != len(columns) {
+			panic(fmt.Sprintf("VALUES row %d has %d values, expected %d", i+1, len(row), len(columns)))
+		}
+	}
+	values := &valuesSql{rows: v.rows, types: make([]reflect.Type, len(columns))}
+	values.query = values
+	for i, col := range columns {
+		values.types[i] = col.goType()
+	}
+	table := &valuesTable{
+		tableDef:      newTableDef(values, alias),
+		sourceColumns: make([]ParametricSql, len(columns)),
+	}
+	table.deriveColumns(table, columns, true)
+	for i, col := range columns {
+		table.sourceColumns[i] = col
+	}
+	return table, table.columns
+}
+
+// valuesTable is a VALUES list used as a table: (VALUES ...) AS alias (col1, col2).
+type valuesTable struct {
+	*tableDef
+	// sourceColumns are the columns the table columns were named after, for DerivedCol
+	sourceColumns []ParametricSql
+}
+
+var _ Table = &valuesTable{}
+
+func (t *valuesTable) SqlWithParams(params *ParamsMap, ctx RenderContext) (string, *ParamsMap) {
+	requireFeature(params.Dialect(), FeatureDerivedColumnList)
+	sql, params := t.tableDef.SqlWithParams(params, ctx)
+	names := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		names[i] = quoteIdent(params.Dialect(), col.Name())
+	}
+	return sql + " (" + strings.Join(names, ", ") + ")", params
+}
+
+type valuesSql struct {
+	renderer
+	rows []ValuesRow
+	// types are the Go types of the columns, which the parameters of the first row are cast to
+	types []reflect.Type
+}
+
+func (v *valuesSql) SqlWithParams(params *ParamsMap, _ RenderContext) (string, *ParamsMap) {
+	rowsSql := make([]string, len(v.rows))
+	for i, row := range v.rows {
+		valuesSql := make([]string, len(row))
+		for j, value := range row {
+			valuesSql[j], params = value.SqlWithParams(params, ReferenceContext)
+			if _, ok := value.(placeholder); ok && i == 0 {
+				if sqlType, ok := params.Dialect().CastType(v.types[j]); ok {
+					valuesSql[j] = "CAST(" + valuesSql[j] + " AS " + sqlType + ")"
+				}
+			}
+		}
+		rowsSql[i] = params.Dialect().ValuesRow(valuesSql)
+	}
+	return "VALUES " + strings.Join(rowsSql, ", "), params
+}
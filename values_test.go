@@ -0,0 +1,76 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValues(t *testing.T) {
+	t.Run("join against parameters", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureDerivedColumnList}})
+
+		priority := NewCol[int]("priority", nil)
+		prio, cols := Values(
+			Row(Param(int64(1)), Param(3)),
+			Row(Param(int64(2)), Param(1)),
+			Row(Param(int64(3)), Param(3)),
+		).As("prio", Account.Id, priority)
+
+		prioId := DerivedCol(prio, Account.Id)
+		prioPriority := DerivedCol(prio, priority)
+
+		sql, params := Select(Account.Uuid, prioPriority).From(Account).
+			Join(prio).On(prioId.Eq(Account.Id)).
+			Where(prioPriority.GtParam(1)).
+			OrderBy(prioPriority.Desc()).
+			SQL()
+
+		require.Equal(t, "SELECT account.uuid, prio.priority FROM account "+
+			"JOIN (VALUES (CAST($1 AS BIGINT), CAST($2 AS INTEGER)), ($3, $4), ($5, $2)) AS prio (id, priority) ON prio.id = account.id "+
+			"WHERE prio.priority > $4 ORDER BY prio.priority DESC", sql)
+		require.Equal(t, []any{int64(1), 3, int64(2), 1, int64(3)}, params)
+
+		require.Len(t, cols, 2)
+		require.IsType(t, &Col[int64]{}, cols[0])
+		require.IsType(t, &Col[int]{}, cols[1])
+		require.Equal(t, "priority", cols[1].Name())
+		require.Equal(t, prio, cols[1].Table())
+	})
+
+	t.Run("as from source with literals", func(t *testing.T) {
+		label := NewCol[string]("label", nil)
+		labels, cols := Values(Row(Literal("a"), Literal(1)), Row(Literal("it's"), Literal(2))).
+			As("labels", label, Account.CreatedTs)
+
+		sql, params := Select(cols[0], cols[1]).From(labels).
+			Where(DerivedCol(labels, Account.CreatedTs).Gt(Literal(1))).
+			SQL()
+
+		require.Equal(t, "SELECT labels.label, labels.created_ts FROM (VALUES ('a', 1), ('it''s', 2)) AS labels (label, created_ts) "+
+			"WHERE labels.created_ts > 1", sql)
+		require.Empty(t, params)
+	})
+
+	t.Run("first row parameters are typed", func(t *testing.T) {
+		withDialect(t, &numberedTestDialect{features: []Feature{FeatureDerivedColumnList}})
+
+		label := NewCol[string]("label", nil)
+		raw := NewCol[any]("raw", nil)
+		labels, _ := Values(Row(Literal("a"), Param(int64(1)), Param(2)), Row(Param("b"), Param(int64(3)), Param(4))).
+			As("labels", label, Account.Id, raw)
+
+		sql, params := Select(DerivedCol(labels, label)).From(labels).SQL()
+
+		// literals are typed already, and a column of type any has no SQL type to cast to
+		require.Equal(t, "SELECT labels.label FROM (VALUES ('a', CAST($1 AS BIGINT), $2), ($3, $4, $5)) "+
+			"AS labels (label, id, raw)", sql)
+		require.Equal(t, []any{int64(1), 2, "b", int64(3), 4}, params)
+	})
+
+	t.Run("rows of different length", func(t *testing.T) {
+		require.PanicsWithValue(t, "VALUES row 2 has 1 values, expected 2", func() {
+			Values(Row(Param(1), Param(2)), Row(Param(3))).As("v", Account.Id, Account.CreatedTs)
+		})
+	})
+}
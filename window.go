@@ -0,0 +1,187 @@
+package tomasql
+
+import (
+	"fmt"
+	"strings"
+)
+
+func RowNumber() *WindowFunc[int] {
+	return newWindowFunc[int]("ROW_NUMBER", newMultiParametricSql(""))
+}
+
+func Rank() *WindowFunc[int] {
+	return newWindowFunc[int]("RANK", newMultiParametricSql(""))
+}
+
+func DenseRank() *WindowFunc[int] {
+	return newWindowFunc[int]("DENSE_RANK", newMultiParametricSql(""))
+}
+
+// Lag returns the value of col offset rows before the current row within the window partition.
+func Lag[T any](col ParametricSql, offset int) *WindowFunc[T] {
+	return newWindowFunc[T]("LAG", newMultiParametricSql(", ",
+		[]ParametricSql{col, NewFixedCol(offset, nil)}...))
+}
+
+// Lead returns the value of col offset rows after the current row within the window partition.
+func Lead[T any](col ParametricSql, offset int) *WindowFunc[T] {
+	return newWindowFunc[T]("LEAD", newMultiParametricSql(", ",
+		[]ParametricSql{col, NewFixedCol(offset, nil)}...))
+}
+
+func FirstValue[T any](col ParametricSql) *WindowFunc[T] {
+	return newWindowFunc[T]("FIRST_VALUE", col)
+}
+
+func LastValue[T any](col ParametricSql) *WindowFunc[T] {
+	return newWindowFunc[T]("LAST_VALUE", col)
+}
+
+// WindowFunc is a window function, e.g. ROW_NUMBER or LAG. It can only be used once Over sets its window.
+type WindowFunc[T any] struct {
+	funcName string
+	inner    ParametricSql
+}
+
+func newWindowFunc[T any](funcName string, inner ParametricSql) *WindowFunc[T] {
+	return &WindowFunc[T]{funcName: funcName, inner: inner}
+}
+
+// Over sets the window the function is computed over.
+func (f *WindowFunc[T]) Over(w *WindowDef) *WindowFuncCol[T] {
+	col := &WindowFuncCol[T]{FuncCol: newFuncCol[T](f.funcName, f.inner)}
+	col.over = w
+	return col
+}
+
+// WindowFuncCol is a window function computed over the window given by WindowFunc.Over.
+type WindowFuncCol[T any] struct {
+	*FuncCol[T]
+}
+
+var (
+	_ FuncColumn     = &WindowFuncCol[int]{}
+	_ rowsAggregator = &WindowFuncCol[int]{}
+)
+
+func (f *WindowFuncCol[T]) aggregatesRows() {}
+
+// As sets the alias of the function. The returned column is the function itself.
+func (f *WindowFuncCol[T]) As(alias string) FuncColumn {
+	f.FuncCol.As(alias)
+	return f
+}
+
+// WindowDef describes the window of a window function: OVER (PARTITION BY ... ORDER BY ... frame). Named windows
+// (see As) are rendered by name and must be declared in the WINDOW clause of the query.
+type WindowDef struct {
+	name        *string
+	partitionBy []ParametricSql
+	orderBy     []SortColumn
+	frame       *windowFrame
+}
+
+// Window starts a window definition, to be used with WindowFunc.Over and AggregateCol.Over.
+func Window() *WindowDef {
+	return &WindowDef{}
+}
+
+func (w *WindowDef) PartitionBy(column ParametricSql, columns ...ParametricSql) *WindowDef {
+	w.partitionBy = append([]ParametricSql{column}, columns...)
+	return w
+}
+
+func (w *WindowDef) OrderBy(column SortColumn, columns ...SortColumn) *WindowDef {
+	w.orderBy = append([]SortColumn{column}, columns...)
+	return w
+}
+
+// Rows sets a ROWS BETWEEN start AND end frame.
+func (w *WindowDef) Rows(start, end FrameBound) *WindowDef {
+	w.frame = &windowFrame{unit: "ROWS", start: start, end: end}
+	return w
+}
+
+// Range sets a RANGE BETWEEN start AND end frame.
+func (w *WindowDef) Range(start, end FrameBound) *WindowDef {
+	w.frame = &windowFrame{unit: "RANGE", start: start, end: end}
+	return w
+}
+
+// As names the window. Functions using it render OVER name, and the query must declare it through Window(...).
+func (w *WindowDef) As(name string) *WindowDef {
+	w.name = &name
+	return w
+}
+
+func (w *WindowDef) Name() *string {
+	return w.name
+}
+
+// overSql renders the OVER clause of a function using the window.
+func (w *WindowDef) overSql(params *ParamsMap) (string, *ParamsMap) {
+	if w.name != nil {
+		return " OVER " + quoteIdent(params.Dialect(), *w.name), params
+	}
+	spec, params := w.specSql(params)
+	return " OVER (" + spec + ")", params
+}
+
+// definitionSql renders `name AS (spec)` for the WINDOW clause.
+func (w *WindowDef) definitionSql(params *ParamsMap) (string, *ParamsMap) {
+	if w.name == nil {
+		panic("windows in a WINDOW clause must be named")
+	}
+	spec, params := w.specSql(params)
+	return quoteIdent(params.Dialect(), *w.name) + " AS (" + spec + ")", params
+}
+
+func (w *WindowDef) specSql(params *ParamsMap) (string, *ParamsMap) {
+	var parts []string
+	if len(w.partitionBy) > 0 {
+		var cols []string
+		for _, col := range w.partitionBy {
+			var colSql string
+			colSql, params = col.SqlWithParams(params, ReferenceContext)
+			cols = append(cols, colSql)
+		}
+		parts = append(parts, "PARTITION BY "+strings.Join(cols, ", "))
+	}
+	if len(w.orderBy) > 0 {
+		var orderBySql string
+		orderBySql, params = aggregateOrderBySql(w.orderBy, params)
+		parts = append(parts, orderBySql)
+	}
+	if w.frame != nil {
+		parts = append(parts, w.frame.sql())
+	}
+	return strings.Join(parts, " "), params
+}
+
+type windowFrame struct {
+	unit       string
+	start, end FrameBound
+}
+
+func (f *windowFrame) sql() string {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", f.unit, f.start, f.end)
+}
+
+// FrameBound is the start or end of a window frame.
+type FrameBound string
+
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding is the frame bound n rows (or values, for RANGE) before the current row.
+func Preceding(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d PRECEDING", n))
+}
+
+// Following is the frame bound n rows (or values, for RANGE) after the current row.
+func Following(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d FOLLOWING", n))
+}
@@ -0,0 +1,155 @@
+package tomasql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		col      ParametricSql
+		expected string
+	}{
+		{
+			name:     "RowNumber",
+			col:      RowNumber().Over(Window().OrderBy(Account.CreatedTs.Asc())),
+			expected: "ROW_NUMBER() OVER (ORDER BY account.created_ts ASC)",
+		},
+		{
+			name:     "Rank",
+			col:      Rank().Over(Window().PartitionBy(Account.Type).OrderBy(Account.CreatedTs.Desc())),
+			expected: "RANK() OVER (PARTITION BY account.type ORDER BY account.created_ts DESC)",
+		},
+		{
+			name:     "DenseRank",
+			col:      DenseRank().Over(Window().PartitionBy(Account.Type, Account.Uuid).OrderBy(Account.Id.Asc())),
+			expected: "DENSE_RANK() OVER (PARTITION BY account.type, account.uuid ORDER BY account.id ASC)",
+		},
+		{
+			name:     "Lag",
+			col:      Lag[int](Account.CreatedTs, 1).Over(Window().OrderBy(Account.CreatedTs.Asc())),
+			expected: "LAG(account.created_ts, 1) OVER (ORDER BY account.created_ts ASC)",
+		},
+		{
+			name:     "Lead",
+			col:      Lead[int](Account.CreatedTs, 2).Over(Window().OrderBy(Account.CreatedTs.Asc())),
+			expected: "LEAD(account.created_ts, 2) OVER (ORDER BY account.created_ts ASC)",
+		},
+		{
+			name:     "FirstValue",
+			col:      FirstValue[string](Account.Uuid).Over(Window().PartitionBy(Account.Type).OrderBy(Account.CreatedTs.Asc())),
+			expected: "FIRST_VALUE(account.uuid) OVER (PARTITION BY account.type ORDER BY account.created_ts ASC)",
+		},
+		{
+			name: "LastValue with frame",
+			col: LastValue[string](Account.Uuid).Over(Window().
+				OrderBy(Account.CreatedTs.Asc()).
+				Rows(UnboundedPreceding, UnboundedFollowing)),
+			expected: "LAST_VALUE(account.uuid) OVER (ORDER BY account.created_ts ASC ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)",
+		},
+		{
+			name:     "aggregate with range frame",
+			col:      Sum[int](Account.CreatedTs).Over(Window().OrderBy(Account.Id.Asc()).Range(Preceding(10), CurrentRow)),
+			expected: "SUM(account.created_ts) OVER (ORDER BY account.id ASC RANGE BETWEEN 10 PRECEDING AND CURRENT ROW)",
+		},
+		{
+			name:     "empty window",
+			col:      Count().Over(Window()),
+			expected: "COUNT(1) OVER ()",
+		},
+		{
+			name:     "rows frame with offsets",
+			col:      Avg[float64](Account.CreatedTs).Over(Window().OrderBy(Account.Id.Asc()).Rows(Preceding(3), Following(3))),
+			expected: "AVG(account.created_ts) OVER (ORDER BY account.id ASC ROWS BETWEEN 3 PRECEDING AND 3 FOLLOWING)",
+		},
+		{
+			name:     "named window",
+			col:      RowNumber().Over(Window().As("w")),
+			expected: "ROW_NUMBER() OVER w",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _ := tt.col.SqlWithParams(&ParamsMap{}, ReferenceContext)
+			require.Equal(t, tt.expected, sql)
+		})
+	}
+}
+
+func TestWindowInQuery(t *testing.T) {
+	t.Run("select and order by aliased window function", func(t *testing.T) {
+		rn := RowNumber().Over(Window().PartitionBy(Account.Type).OrderBy(Account.CreatedTs.Desc()))
+
+		sql, params := Select(Account.Id, rn.As("rn")).
+			From(Account).
+			Where(Account.Type.EqParam("user")).
+			OrderBy(rn.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT account.id, ROW_NUMBER() OVER (PARTITION BY account.type ORDER BY account.created_ts DESC) AS rn "+
+			"FROM account WHERE account.type = ? ORDER BY rn ASC", sql)
+		require.Equal(t, []any{"user"}, params)
+	})
+
+	t.Run("named windows", func(t *testing.T) {
+		byType := Window().PartitionBy(Account.Type).OrderBy(Account.CreatedTs.Asc()).As("by_type")
+		recent := Window().OrderBy(Account.CreatedTs.Desc()).Rows(Preceding(1), CurrentRow).As("recent")
+
+		sql, _ := Select(Account.Id, Rank().Over(byType), Lag[string](Account.Uuid, 1).Over(byType), Sum[int](Account.CreatedTs).Over(recent)).
+			From(Account).
+			Where(Account.Type.IsNotNull()).
+			Window(byType, recent).
+			OrderBy(Account.Id.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT account.id, RANK() OVER by_type, LAG(account.uuid, 1) OVER by_type, SUM(account.created_ts) OVER recent "+
+			"FROM account WHERE account.type IS NOT NULL "+
+			"WINDOW by_type AS (PARTITION BY account.type ORDER BY account.created_ts ASC), "+
+			"recent AS (ORDER BY account.created_ts DESC ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) "+
+			"ORDER BY account.id ASC", sql)
+	})
+
+	t.Run("window after group by", func(t *testing.T) {
+		w := Window().OrderBy(Count().Desc()).As("w")
+
+		sql, _ := Select(Account.Type, Count().As("total"), Rank().Over(w)).
+			From(Account).
+			GroupBy(Account.Type).
+			Window(w).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, COUNT(1) AS total, RANK() OVER w "+
+			"FROM account GROUP BY account.type WINDOW w AS (ORDER BY COUNT(1) DESC)", sql)
+	})
+
+	t.Run("aliased sort column in window", func(t *testing.T) {
+		created := Account.CreatedTs.As("c")
+
+		sql, _ := Select(Account.Id, RowNumber().Over(Window().OrderBy(created.Asc())).As("rn")).
+			From(Account).
+			OrderBy(created.Asc()).
+			SQL()
+
+		require.Equal(t, "SELECT account.id, ROW_NUMBER() OVER (ORDER BY account.created_ts ASC) AS rn "+
+			"FROM account ORDER BY c ASC", sql)
+	})
+
+	t.Run("aliased sort column in named window", func(t *testing.T) {
+		w := Window().OrderBy(Count().As("total").Desc()).As("w")
+
+		sql, _ := Select(Account.Type, Count().As("total"), Rank().Over(w)).
+			From(Account).
+			GroupBy(Account.Type).
+			Window(w).
+			SQL()
+
+		require.Equal(t, "SELECT account.type, COUNT(1) AS total, RANK() OVER w "+
+			"FROM account GROUP BY account.type WINDOW w AS (ORDER BY COUNT(1) DESC)", sql)
+	})
+
+	t.Run("panics on unnamed window in WINDOW clause", func(t *testing.T) {
+		require.Panics(t, func() { Select(Account.Id).From(Account).Window(Window()) })
+	})
+}